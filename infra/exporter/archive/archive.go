@@ -0,0 +1,156 @@
+// Package archive optionally persists each gateway poll to a local BoltDB
+// file with a rolling retention window, and derives longer-horizon metrics
+// (rolling RSRP averages, handover counts, band dwell time) that a single
+// poll can't provide on its own.
+package archive
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+
+	"github.com/tmobile-dashboard/exporter/gateway"
+)
+
+var samplesBucket = []byte("samples")
+
+// Sample is a single archived poll of a gateway.
+type Sample struct {
+	Device string                `json:"device"`
+	Time   time.Time             `json:"time"`
+	Status gateway.GatewayStatus `json:"status"`
+}
+
+// Archive persists GatewayStatus samples to a local BoltDB file and prunes
+// samples older than Retention as new ones are recorded.
+type Archive struct {
+	db        *bolt.DB
+	retention time.Duration
+}
+
+// Open opens (creating if necessary) a BoltDB archive at path. Samples
+// older than retention are pruned as new samples come in; a retention of
+// zero disables pruning.
+func Open(path string, retention time.Duration) (*Archive, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open archive at %s: %w", path, err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(samplesBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to initialize archive schema: %w", err)
+	}
+
+	return &Archive{db: db, retention: retention}, nil
+}
+
+// sampleKey encodes a sortable bucket key: the device name, a NUL
+// separator, then the sample time as big-endian Unix nanoseconds. This
+// lets range queries for a device's history use a plain cursor seek.
+func sampleKey(device string, at time.Time) []byte {
+	b := make([]byte, len(device)+1+8)
+	copy(b, device)
+	b[len(device)] = 0
+	binary.BigEndian.PutUint64(b[len(device)+1:], uint64(at.UnixNano()))
+	return b
+}
+
+// Record persists a single poll sample and prunes anything older than
+// Retention for that device.
+func (a *Archive) Record(device string, at time.Time, status *gateway.GatewayStatus) error {
+	sample := Sample{Device: device, Time: at, Status: *status}
+	data, err := json.Marshal(sample)
+	if err != nil {
+		return fmt.Errorf("failed to encode sample: %w", err)
+	}
+
+	err = a.db.Update(func(tx *bolt.Tx) error {
+		b := tx.Bucket(samplesBucket)
+		if err := b.Put(sampleKey(device, at), data); err != nil {
+			return err
+		}
+		return a.prune(b, device, at)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record sample: %w", err)
+	}
+	return nil
+}
+
+// prune deletes samples for device older than Retention, relative to now.
+// Must be called from within an update transaction on b.
+func (a *Archive) prune(b *bolt.Bucket, device string, now time.Time) error {
+	if a.retention <= 0 {
+		return nil
+	}
+
+	prefix := append([]byte(device), 0)
+	cutoff := sampleKey(device, now.Add(-a.retention))
+
+	c := b.Cursor()
+	var stale [][]byte
+	for k, _ := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix) && bytes.Compare(k, cutoff) < 0; k, _ = c.Next() {
+		stale = append(stale, append([]byte(nil), k...))
+	}
+	for _, k := range stale {
+		if err := b.Delete(k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Query returns every sample for device with a time in [from, to], ordered oldest-first.
+func (a *Archive) Query(device string, from, to time.Time) ([]Sample, error) {
+	var samples []Sample
+	err := a.db.View(func(tx *bolt.Tx) error {
+		b := tx.Bucket(samplesBucket)
+		c := b.Cursor()
+
+		start := sampleKey(device, from)
+		end := sampleKey(device, to)
+		for k, v := c.Seek(start); k != nil && bytes.Compare(k, end) <= 0; k, v = c.Next() {
+			var s Sample
+			if err := json.Unmarshal(v, &s); err != nil {
+				return fmt.Errorf("failed to decode sample: %w", err)
+			}
+			samples = append(samples, s)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query archive: %w", err)
+	}
+	return samples, nil
+}
+
+// RSRPAverage returns the mean Signal.RSRP across samples for device in [from, to].
+func (a *Archive) RSRPAverage(device string, from, to time.Time) (float64, error) {
+	samples, err := a.Query(device, from, to)
+	if err != nil {
+		return 0, err
+	}
+	if len(samples) == 0 {
+		return 0, nil
+	}
+
+	var sum float64
+	for _, s := range samples {
+		sum += s.Status.Signal.RSRP
+	}
+	return sum / float64(len(samples)), nil
+}
+
+// Close releases the underlying BoltDB file.
+func (a *Archive) Close() error {
+	return a.db.Close()
+}
@@ -0,0 +1,150 @@
+package archive
+
+import (
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/tmobile-dashboard/exporter/gateway"
+)
+
+// rsrpAvgWindow is the trailing window over which the rolling RSRP average
+// is computed.
+const rsrpAvgWindow = 24 * time.Hour
+
+// cellState is the last known serving cell for a device, used to detect
+// handovers and attribute band dwell time as new samples arrive.
+type cellState struct {
+	pci  int64
+	enb  int64
+	band int64
+	at   time.Time
+}
+
+// rsrpSample is a single RSRP reading kept in a device's rolling window.
+type rsrpSample struct {
+	at   time.Time
+	rsrp float64
+}
+
+// Collector implements metrics.SampleObserver and prometheus.Collector. It
+// persists every sample to an Archive and derives metrics that need to see
+// more than the latest poll: a rolling RSRP average, cumulative handover
+// count, and per-band dwell time.
+type Collector struct {
+	archive *Archive
+
+	mu            sync.Mutex
+	prevCell      map[string]cellState
+	handoverTotal map[string]float64
+	bandSeconds   map[string]map[int64]float64
+	rsrpSamples   map[string][]rsrpSample
+	rsrpSum       map[string]float64
+
+	rsrpAvg24hDesc    *prometheus.Desc
+	handoverTotalDesc *prometheus.Desc
+	bandSecondsDesc   *prometheus.Desc
+}
+
+// NewCollector creates a Collector that persists samples to a. a may be
+// nil to derive handover/band metrics without archiving history.
+func NewCollector(a *Archive) *Collector {
+	return &Collector{
+		archive:       a,
+		prevCell:      make(map[string]cellState),
+		handoverTotal: make(map[string]float64),
+		bandSeconds:   make(map[string]map[int64]float64),
+		rsrpSamples:   make(map[string][]rsrpSample),
+		rsrpSum:       make(map[string]float64),
+
+		rsrpAvg24hDesc: prometheus.NewDesc(
+			"tmobile_signal_rsrp_avg_24h",
+			"Average RSRP in dBm over the trailing 24 hours",
+			[]string{"device"},
+			nil,
+		),
+		handoverTotalDesc: prometheus.NewDesc(
+			"tmobile_cell_handover_total",
+			"Count of times the serving cell's PCI or eNB ID has changed",
+			[]string{"device"},
+			nil,
+		),
+		bandSecondsDesc: prometheus.NewDesc(
+			"tmobile_band_seconds_total",
+			"Cumulative seconds spent on each frequency band",
+			[]string{"device", "band"},
+			nil,
+		),
+	}
+}
+
+// Observe implements metrics.SampleObserver. It updates handover/band-dwell
+// state and persists the sample to the archive, if configured.
+func (c *Collector) Observe(device string, at time.Time, status *gateway.GatewayStatus) {
+	c.mu.Lock()
+	prev, ok := c.prevCell[device]
+	if ok {
+		if c.bandSeconds[device] == nil {
+			c.bandSeconds[device] = make(map[int64]float64)
+		}
+		c.bandSeconds[device][prev.band] += at.Sub(prev.at).Seconds()
+
+		if prev.pci != status.Cell.PCI || prev.enb != status.Cell.ENB {
+			c.handoverTotal[device]++
+		}
+	}
+	c.prevCell[device] = cellState{pci: status.Cell.PCI, enb: status.Cell.ENB, band: status.Cell.Band, at: at}
+
+	c.rsrpSamples[device] = append(c.rsrpSamples[device], rsrpSample{at: at, rsrp: status.Signal.RSRP})
+	c.rsrpSum[device] += status.Signal.RSRP
+	cutoff := at.Add(-rsrpAvgWindow)
+	samples := c.rsrpSamples[device]
+	expired := 0
+	for expired < len(samples) && samples[expired].at.Before(cutoff) {
+		c.rsrpSum[device] -= samples[expired].rsrp
+		expired++
+	}
+	if expired > 0 {
+		c.rsrpSamples[device] = samples[expired:]
+	}
+	c.mu.Unlock()
+
+	if c.archive != nil {
+		if err := c.archive.Record(device, at, status); err != nil {
+			log.Printf("Error archiving sample for %s: %v", device, err)
+		}
+	}
+}
+
+// Describe implements prometheus.Collector.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.rsrpAvg24hDesc
+	ch <- c.handoverTotalDesc
+	ch <- c.bandSecondsDesc
+}
+
+// Collect implements prometheus.Collector.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for device, total := range c.handoverTotal {
+		ch <- prometheus.MustNewConstMetric(c.handoverTotalDesc, prometheus.CounterValue, total, device)
+	}
+	for device, bands := range c.bandSeconds {
+		for band, seconds := range bands {
+			ch <- prometheus.MustNewConstMetric(c.bandSecondsDesc, prometheus.CounterValue, seconds, device, strconv.FormatInt(band, 10))
+		}
+	}
+
+	for device, samples := range c.rsrpSamples {
+		if len(samples) == 0 {
+			continue
+		}
+		avg := c.rsrpSum[device] / float64(len(samples))
+		ch <- prometheus.MustNewConstMetric(c.rsrpAvg24hDesc, prometheus.GaugeValue, avg, device)
+	}
+}
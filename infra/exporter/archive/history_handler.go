@@ -0,0 +1,77 @@
+package archive
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// HistoryHandler serves archived samples for a device as JSON or CSV, e.g.
+//
+//	GET /history?device=main&since=2024-01-01T00:00:00Z&format=csv
+//
+// device is the gateway's metrics device label (its configured name, or its
+// URL if no name is set); it defaults to "", which matches no recorded
+// samples. since defaults to 24 hours ago, and format defaults to json.
+func HistoryHandler(a *Archive) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		device := r.URL.Query().Get("device")
+
+		format := r.URL.Query().Get("format")
+		if format == "" {
+			format = "json"
+		}
+
+		since := time.Now().Add(-24 * time.Hour)
+		if s := r.URL.Query().Get("since"); s != "" {
+			parsed, err := time.Parse(time.RFC3339, s)
+			if err != nil {
+				http.Error(w, fmt.Sprintf("invalid since: %v", err), http.StatusBadRequest)
+				return
+			}
+			since = parsed
+		}
+
+		samples, err := a.Query(device, since, time.Now())
+		if err != nil {
+			http.Error(w, fmt.Sprintf("failed to query archive: %v", err), http.StatusInternalServerError)
+			return
+		}
+
+		switch format {
+		case "csv":
+			writeCSV(w, samples)
+		case "json":
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(samples)
+		default:
+			http.Error(w, fmt.Sprintf("unsupported format %q", format), http.StatusBadRequest)
+		}
+	}
+}
+
+func writeCSV(w http.ResponseWriter, samples []Sample) {
+	w.Header().Set("Content-Type", "text/csv")
+	cw := csv.NewWriter(w)
+	defer cw.Flush()
+
+	cw.Write([]string{"time", "device", "model", "rsrp", "rsrq", "sinr", "rssi", "pci", "enb", "tac", "band"})
+	for _, s := range samples {
+		cw.Write([]string{
+			s.Time.Format(time.RFC3339),
+			s.Device,
+			s.Status.Model,
+			strconv.FormatFloat(s.Status.Signal.RSRP, 'f', 1, 64),
+			strconv.FormatFloat(s.Status.Signal.RSRQ, 'f', 1, 64),
+			strconv.FormatFloat(s.Status.Signal.SINR, 'f', 1, 64),
+			strconv.FormatFloat(s.Status.Signal.RSSI, 'f', 1, 64),
+			strconv.FormatInt(s.Status.Cell.PCI, 10),
+			strconv.FormatInt(s.Status.Cell.ENB, 10),
+			strconv.FormatInt(s.Status.Cell.TAC, 10),
+			strconv.FormatInt(s.Status.Cell.Band, 10),
+		})
+	}
+}
@@ -0,0 +1,275 @@
+// 5g-inspector is a CLI for diagnosing T-Mobile 5G/LTE home internet
+// gateways without needing Prometheus+Grafana set up.
+//
+// Usage:
+//
+//	5g-inspector status [flags]
+//	5g-inspector track [flags]
+//	5g-inspector serve [flags]
+//
+// Flags:
+//
+//	-config string   Path to config file (default: no config file)
+//	-json            Output a single JSON status snapshot
+//	-yaml            Output a single YAML status snapshot
+//	-watch           Keep polling and redraw the dashboard on each poll
+//
+// track flags:
+//
+//	-config string       Path to config file (default: no config file)
+//	-dir string          Directory to archive samples to (required)
+//	-interval duration   Poll interval (default: 30s)
+//	-gzip-after duration Compress archive files older than this (default: disabled)
+//
+// serve flags:
+//
+//	-config string     Path to config file (default: no config file)
+//	-port int          Port to serve on (default: 9100)
+//	-interval duration Poll interval (default: 5s)
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"gopkg.in/yaml.v3"
+
+	"github.com/tmobile-dashboard/exporter/config"
+	"github.com/tmobile-dashboard/exporter/gateway"
+	"github.com/tmobile-dashboard/exporter/gateway/exporter"
+	"github.com/tmobile-dashboard/exporter/gateway/tracker"
+	"github.com/tmobile-dashboard/exporter/tui"
+)
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+		os.Exit(1)
+	}
+
+	switch os.Args[1] {
+	case "status":
+		runStatus(os.Args[2:])
+	case "track":
+		runTrack(os.Args[2:])
+	case "serve":
+		runServe(os.Args[2:])
+	case "-h", "-help", "--help":
+		usage()
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q\n", os.Args[1])
+		usage()
+		os.Exit(1)
+	}
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: 5g-inspector <command> [flags]")
+	fmt.Fprintln(os.Stderr, "commands:")
+	fmt.Fprintln(os.Stderr, "  status   show live signal/cell diagnostics for the configured gateway")
+	fmt.Fprintln(os.Stderr, "  track    poll the gateway as a service and archive signal history to disk")
+	fmt.Fprintln(os.Stderr, "  serve    expose /metrics, /status.json, and /events for the configured gateway")
+}
+
+func runStatus(args []string) {
+	fs := flag.NewFlagSet("status", flag.ExitOnError)
+	configPath := fs.String("config", "", "Path to config file")
+	jsonOut := fs.Bool("json", false, "Output a single JSON status snapshot")
+	yamlOut := fs.Bool("yaml", false, "Output a single YAML status snapshot")
+	watch := fs.Bool("watch", false, "Keep polling and redraw the dashboard on each poll")
+	fs.Parse(args)
+
+	cfg, err := config.LoadConfig(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to load config: %v\n", err)
+		os.Exit(1)
+	}
+	config.LoadConfigFromEnv(cfg)
+
+	gwClient, err := gateway.NewClient(cfg.Gateways[0].ToClientConfig())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to create gateway client: %v\n", err)
+		os.Exit(1)
+	}
+	defer gwClient.Close()
+
+	pollInterval := cfg.Gateways[0].PollInterval
+	if pollInterval <= 0 {
+		pollInterval = 5 * time.Second
+	}
+
+	// Rolling 60-second SINR history, sampled once per poll.
+	historyLen := int(60 / pollInterval.Seconds())
+	if historyLen < 1 {
+		historyLen = 1
+	}
+	var sinrHistory []float64
+
+	for {
+		status, err := gwClient.GetStatus()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "failed to get gateway status: %v\n", err)
+			if !*watch {
+				os.Exit(1)
+			}
+		} else {
+			sinrHistory = append(sinrHistory, status.Signal.SINR)
+			if len(sinrHistory) > historyLen {
+				sinrHistory = sinrHistory[len(sinrHistory)-historyLen:]
+			}
+			printStatus(status, sinrHistory, pollInterval, *jsonOut, *yamlOut, *watch)
+		}
+
+		if !*watch {
+			return
+		}
+		time.Sleep(pollInterval)
+	}
+}
+
+func printStatus(status *gateway.GatewayStatus, sinrHistory []float64, pollInterval time.Duration, jsonOut, yamlOut, watch bool) {
+	switch {
+	case jsonOut:
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		enc.Encode(status)
+	case yamlOut:
+		enc := yaml.NewEncoder(os.Stdout)
+		enc.Encode(status)
+		enc.Close()
+	default:
+		if watch {
+			fmt.Print("\033[H\033[2J") // clear screen before each redraw
+		}
+		fmt.Print(tui.Dashboard(status, sinrHistory, pollInterval))
+	}
+}
+
+// runTrack polls the configured gateway as a long-running service,
+// archiving every sample to disk so signal history can be reviewed after
+// the fact. Runs until interrupted.
+func runTrack(args []string) {
+	fs := flag.NewFlagSet("track", flag.ExitOnError)
+	configPath := fs.String("config", "", "Path to config file")
+	dir := fs.String("dir", "", "Directory to archive samples to (required)")
+	interval := fs.Duration("interval", 30*time.Second, "Poll interval")
+	gzipAfter := fs.Duration("gzip-after", 0, "Compress archive files older than this (0 disables compression)")
+	fs.Parse(args)
+
+	if *dir == "" {
+		fmt.Fprintln(os.Stderr, "track: -dir is required")
+		os.Exit(1)
+	}
+
+	cfg, err := config.LoadConfig(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to load config: %v\n", err)
+		os.Exit(1)
+	}
+	config.LoadConfigFromEnv(cfg)
+
+	gwClient, err := gateway.NewClient(cfg.Gateways[0].ToClientConfig())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to create gateway client: %v\n", err)
+		os.Exit(1)
+	}
+	defer gwClient.Close()
+
+	t, err := tracker.New(gwClient, tracker.Config{
+		Dir:          *dir,
+		PollInterval: *interval,
+		GzipAfter:    *gzipAfter,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to start tracker: %v\n", err)
+		os.Exit(1)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-sigChan
+		log.Println("Shutting down...")
+		cancel()
+	}()
+
+	log.Printf("Tracking %s, archiving to %s every %s", cfg.Gateways[0].URL, *dir, *interval)
+	if err := t.Run(ctx); err != nil {
+		fmt.Fprintf(os.Stderr, "tracker stopped: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// runServe polls the configured gateway as a long-running service and
+// exposes its status over HTTP, so the gateway can be wired into
+// Grafana/alerting instead of only being inspected via the status/track
+// commands. Runs until interrupted.
+func runServe(args []string) {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	configPath := fs.String("config", "", "Path to config file")
+	port := fs.Int("port", 9100, "Port to serve on")
+	interval := fs.Duration("interval", 5*time.Second, "Poll interval")
+	fs.Parse(args)
+
+	cfg, err := config.LoadConfig(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to load config: %v\n", err)
+		os.Exit(1)
+	}
+	config.LoadConfigFromEnv(cfg)
+
+	gwClient, err := gateway.NewClient(cfg.Gateways[0].ToClientConfig())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to create gateway client: %v\n", err)
+		os.Exit(1)
+	}
+	defer gwClient.Close()
+
+	exp := exporter.New(gwClient, exporter.Config{PollInterval: *interval})
+	prometheus.MustRegister(exp)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	exp.Start(ctx)
+	defer exp.Close()
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.Handler())
+	mux.HandleFunc("/status.json", exp.StatusHandler())
+	mux.HandleFunc("/events", exp.EventsHandler())
+
+	server := &http.Server{
+		Addr:         fmt.Sprintf(":%d", *port),
+		Handler:      mux,
+		ReadTimeout:  10 * time.Second,
+		WriteTimeout: 0, // /events streams indefinitely
+	}
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-sigChan
+		log.Println("Shutting down...")
+		cancel()
+
+		shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer shutdownCancel()
+		server.Shutdown(shutdownCtx)
+	}()
+
+	log.Printf("Serving %s at http://localhost:%d (/metrics, /status.json, /events)", cfg.Gateways[0].URL, *port)
+	if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+		fmt.Fprintf(os.Stderr, "HTTP server error: %v\n", err)
+		os.Exit(1)
+	}
+}
@@ -14,18 +14,32 @@ import (
 
 // Config holds the application configuration.
 type Config struct {
-	// Gateway configuration
-	Gateway GatewayConfig `yaml:"gateway"`
+	// Gateway holds settings for a single gateway. Deprecated: use
+	// Gateways instead; kept for back-compat with existing config files.
+	// If set and Gateways is empty, it is treated as a one-element
+	// Gateways list.
+	Gateway *GatewayConfig `yaml:"gateway,omitempty"`
+
+	// Gateways holds settings for every gateway to monitor, letting a
+	// single exporter instance scrape multiple sites or households.
+	Gateways []GatewayConfig `yaml:"gateways"`
 
 	// Metrics server configuration
 	Metrics MetricsConfig `yaml:"metrics"`
 
 	// Logging configuration
 	Logging LoggingConfig `yaml:"logging"`
+
+	// Archive configuration for historical samples. Disabled by default.
+	Archive ArchiveConfig `yaml:"archive"`
 }
 
 // GatewayConfig holds gateway connection settings.
 type GatewayConfig struct {
+	// Name identifies this gateway in metrics (the "device" label).
+	// Defaults to the gateway URL if empty.
+	Name string `yaml:"name"`
+
 	// URL is the base URL of the gateway
 	URL string `yaml:"url"`
 
@@ -66,15 +80,31 @@ type LoggingConfig struct {
 	Format string `yaml:"format"`
 }
 
+// ArchiveConfig holds settings for optionally persisting samples to a local
+// historical archive (see package archive).
+type ArchiveConfig struct {
+	// Enabled turns on the archive. Off by default.
+	Enabled bool `yaml:"enabled"`
+
+	// Path is the BoltDB file to persist samples to.
+	Path string `yaml:"path"`
+
+	// Retention is how long to keep samples before they're pruned. Zero
+	// disables pruning and keeps samples forever.
+	Retention time.Duration `yaml:"retention"`
+}
+
 // DefaultConfig returns a Config with sensible defaults.
 func DefaultConfig() Config {
 	return Config{
-		Gateway: GatewayConfig{
-			URL:                "http://192.168.12.1",
-			Model:              "auto",
-			PollInterval:       5 * time.Second,
-			Timeout:            10 * time.Second,
-			InsecureSkipVerify: true,
+		Gateways: []GatewayConfig{
+			{
+				URL:                "http://192.168.12.1",
+				Model:              "auto",
+				PollInterval:       5 * time.Second,
+				Timeout:            10 * time.Second,
+				InsecureSkipVerify: true,
+			},
 		},
 		Metrics: MetricsConfig{
 			Port: 9100,
@@ -84,6 +114,11 @@ func DefaultConfig() Config {
 			Level:  "info",
 			Format: "text",
 		},
+		Archive: ArchiveConfig{
+			Enabled:   false,
+			Path:      "tmobile-archive.db",
+			Retention: 7 * 24 * time.Hour,
+		},
 	}
 }
 
@@ -104,27 +139,55 @@ func LoadConfig(path string) (*Config, error) {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
+	// Unmarshaling into cfg merges onto the defaults already populated
+	// above for scalar/struct fields, but Gateways is a slice: if we left
+	// it seeded with the default entry, the legacy-key check below would
+	// never see it as empty. Clear it first so we can tell whether the
+	// file actually set "gateways", "gateway", or neither.
+	defaultGateways := cfg.Gateways
+	cfg.Gateways = nil
+
 	if err := yaml.Unmarshal(data, &cfg); err != nil {
 		return nil, fmt.Errorf("failed to parse config file: %w", err)
 	}
 
+	// Back-compat: a config file using the old singular "gateway" key is
+	// treated as a one-element "gateways" list.
+	if len(cfg.Gateways) == 0 && cfg.Gateway != nil {
+		cfg.Gateways = []GatewayConfig{*cfg.Gateway}
+	}
+
+	// Neither "gateways" nor the legacy "gateway" key was set; fall back
+	// to the default single gateway.
+	if len(cfg.Gateways) == 0 {
+		cfg.Gateways = defaultGateways
+	}
+
 	return &cfg, nil
 }
 
 // LoadConfigFromEnv loads configuration from environment variables.
-// Environment variables override values from the config file.
+// Environment variables override values from the config file. They apply
+// to the first configured gateway, as a convenience for the common
+// single-gateway deployment; multi-gateway setups should use the config
+// file's "gateways" list instead.
 func LoadConfigFromEnv(cfg *Config) {
+	if len(cfg.Gateways) == 0 {
+		cfg.Gateways = []GatewayConfig{{}}
+	}
+	gw := &cfg.Gateways[0]
+
 	if url := os.Getenv("TMOBILE_GATEWAY_URL"); url != "" {
-		cfg.Gateway.URL = url
+		gw.URL = url
 	}
 
 	if model := os.Getenv("TMOBILE_GATEWAY_MODEL"); model != "" {
-		cfg.Gateway.Model = model
+		gw.Model = model
 	}
 
 	if interval := os.Getenv("TMOBILE_POLL_INTERVAL"); interval != "" {
 		if d, err := time.ParseDuration(interval); err == nil {
-			cfg.Gateway.PollInterval = d
+			gw.PollInterval = d
 		}
 	}
 
@@ -136,11 +199,11 @@ func LoadConfigFromEnv(cfg *Config) {
 	}
 
 	if username := os.Getenv("TMOBILE_GATEWAY_USERNAME"); username != "" {
-		cfg.Gateway.Username = username
+		gw.Username = username
 	}
 
 	if password := os.Getenv("TMOBILE_GATEWAY_PASSWORD"); password != "" {
-		cfg.Gateway.Password = password
+		gw.Password = password
 	}
 
 	if level := os.Getenv("TMOBILE_LOG_LEVEL"); level != "" {
@@ -148,10 +211,10 @@ func LoadConfigFromEnv(cfg *Config) {
 	}
 }
 
-// ToGatewayConfig converts the config to a gateway.ClientConfig.
-func (c *Config) ToGatewayConfig() gateway.ClientConfig {
+// ToClientConfig converts a GatewayConfig to a gateway.ClientConfig.
+func (g GatewayConfig) ToClientConfig() gateway.ClientConfig {
 	model := gateway.ModelUnknown
-	switch strings.ToLower(c.Gateway.Model) {
+	switch strings.ToLower(g.Model) {
 	case "arcadyan_kvd21", "arcadyan", "kvd21":
 		model = gateway.ModelArcadyanKVD21
 	case "nokia":
@@ -163,11 +226,20 @@ func (c *Config) ToGatewayConfig() gateway.ClientConfig {
 	}
 
 	return gateway.ClientConfig{
-		URL:                c.Gateway.URL,
+		URL:                g.URL,
 		Model:              model,
-		Timeout:            c.Gateway.Timeout,
-		Username:           c.Gateway.Username,
-		Password:           c.Gateway.Password,
-		InsecureSkipVerify: c.Gateway.InsecureSkipVerify,
+		Timeout:            g.Timeout,
+		Username:           g.Username,
+		Password:           g.Password,
+		InsecureSkipVerify: g.InsecureSkipVerify,
+	}
+}
+
+// DeviceName returns the label to use for this gateway in metrics: the
+// configured Name, or the gateway URL if no name was set.
+func (g GatewayConfig) DeviceName() string {
+	if g.Name != "" {
+		return g.Name
 	}
+	return g.URL
 }
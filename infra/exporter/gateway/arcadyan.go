@@ -1,45 +1,79 @@
 package gateway
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // ArcadyanClient implements GatewayClient for the Arcadyan KVD21 gateway.
+//
+// Arcadyan's firmware gates the TMI endpoints behind a nonce-based login;
+// see arcadyanAuthenticator.login() for the handshake. The resulting token
+// is cached and transparently renewed by a tokenManager, so callers of
+// GetStatus and GetDevices never see a re-auth.
 type ArcadyanClient struct {
 	config     ClientConfig
 	httpClient *http.Client
+
+	// tokens is nil when no credentials are configured, in which case
+	// requests are made without an Authorization header.
+	tokens *tokenManager
+}
+
+// arcadyanAuthenticator implements authenticator for Arcadyan's
+// nonce-based login handshake.
+type arcadyanAuthenticator struct {
+	config     ClientConfig
+	httpClient *http.Client
+}
+
+// arcadyanNonce is returned by a pre-login GET and seeds the password hash.
+type arcadyanNonce struct {
+	Nonce string `json:"nonce"`
+}
+
+// arcadyanLoginResponse carries the session token issued after a
+// successful login and its time-to-live, in seconds.
+type arcadyanLoginResponse struct {
+	Token     string `json:"token"`
+	ExpiresIn int64  `json:"expiresIn"`
 }
 
 // arcadyanRadioStatus represents the JSON response from the radio status endpoint.
 type arcadyanRadioStatus struct {
-	Cell5GStats []arcadyanCellStats `json:"cell_5G_stats_cfg"`
+	Cell5GStats  []arcadyanCellStats `json:"cell_5G_stats_cfg"`
 	CellLTEStats []arcadyanCellStats `json:"cell_LTE_stats_cfg"`
 }
 
 type arcadyanCellStats struct {
-	StatRSRP      string `json:"stat_RSRP"`
-	StatRSRQ      string `json:"stat_RSRQ"`
-	StatRSSI      string `json:"stat_RSSI"`
-	StatSNR       string `json:"stat_SNR"`
-	StatSINR      string `json:"stat_SINR"`
-	StatBand      string `json:"stat_Band"`
-	StatPCI       string `json:"stat_PCI"`
-	StatENBID     string `json:"stat_eNB_ID"`
-	StatCellID    string `json:"stat_Cell_ID"`
-	StatTAC       string `json:"stat_TAC"`
-	PhyCellID     string `json:"stat_PhyCellId"`
-	Bandwidth     string `json:"stat_Bandwidth"`
+	StatRSRP   string `json:"stat_RSRP"`
+	StatRSRQ   string `json:"stat_RSRQ"`
+	StatRSSI   string `json:"stat_RSSI"`
+	StatSNR    string `json:"stat_SNR"`
+	StatSINR   string `json:"stat_SINR"`
+	StatBand   string `json:"stat_Band"`
+	StatPCI    string `json:"stat_PCI"`
+	StatENBID  string `json:"stat_eNB_ID"`
+	StatCellID string `json:"stat_Cell_ID"`
+	StatTAC    string `json:"stat_TAC"`
+	PhyCellID  string `json:"stat_PhyCellId"`
+	Bandwidth  string `json:"stat_Bandwidth"`
+	StatEARFCN string `json:"stat_EARFCN"`
+	StatARFCN  string `json:"stat_ARFCN"`
 }
 
 // arcadyanGatewayInfo represents the JSON response from the gateway info endpoint.
 type arcadyanGatewayInfo struct {
 	Device struct {
-		Model     string `json:"model"`
+		Model      string `json:"model"`
 		IsCellular bool   `json:"isCellular"`
 	} `json:"device"`
 	Connection struct {
@@ -53,10 +87,14 @@ func NewArcadyanClient(cfg ClientConfig, httpClient *http.Client) (*ArcadyanClie
 	if httpClient == nil {
 		return nil, fmt.Errorf("httpClient is required")
 	}
-	return &ArcadyanClient{
+	c := &ArcadyanClient{
 		config:     cfg,
 		httpClient: httpClient,
-	}, nil
+	}
+	if cfg.Username != "" {
+		c.tokens = newTokenManager(&arcadyanAuthenticator{config: cfg, httpClient: httpClient})
+	}
+	return c, nil
 }
 
 // GetStatus retrieves the current gateway status.
@@ -65,7 +103,6 @@ func (c *ArcadyanClient) GetStatus() (*GatewayStatus, error) {
 		Model: string(ModelArcadyanKVD21),
 	}
 
-	// Try the CGI endpoint first (doesn't require authentication)
 	radioStatus, err := c.getRadioStatus()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get radio status: %w", err)
@@ -87,6 +124,8 @@ func (c *ArcadyanClient) GetStatus() (*GatewayStatus, error) {
 		status.Cell = c.parseCellInfo(cellStats)
 	}
 
+	status.Cells = c.buildCellReports(radioStatus)
+
 	status.Connection = ConnectionInfo{
 		Type:   connectionType,
 		Status: "connected",
@@ -95,24 +134,56 @@ func (c *ArcadyanClient) GetStatus() (*GatewayStatus, error) {
 	return status, nil
 }
 
-// getRadioStatus fetches the radio status from the gateway.
-func (c *ArcadyanClient) getRadioStatus() (*arcadyanRadioStatus, error) {
-	// Try the CGI endpoint first
-	url := fmt.Sprintf("%s/fastmile_radio_status_web_app.cgi", c.config.URL)
+// buildCellReports flattens the 5G and LTE stats arrays into a CellReport
+// per entry. The first entry in each array is the serving anchor/leg for
+// its RAT; any additional entries are aggregated component carriers.
+func (c *ArcadyanClient) buildCellReports(radioStatus *arcadyanRadioStatus) []CellReport {
+	var reports []CellReport
 
-	resp, err := c.httpClient.Get(url)
-	if err != nil {
-		return nil, fmt.Errorf("HTTP request failed: %w", err)
+	for i := range radioStatus.Cell5GStats {
+		stats := &radioStatus.Cell5GStats[i]
+		if stats.StatRSRP == "" {
+			continue
+		}
+		role := CellRoleSecondary
+		if i == 0 {
+			role = CellRolePrimary
+		}
+		reports = append(reports, CellReport{
+			Role:   role,
+			RAT:    RATNR,
+			ARFCN:  parseInt(stats.StatARFCN, stats.StatEARFCN),
+			Cell:   c.parseCellInfo(stats),
+			Signal: c.parseSignalMetrics(stats),
+		})
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	for i := range radioStatus.CellLTEStats {
+		stats := &radioStatus.CellLTEStats[i]
+		if stats.StatRSRP == "" {
+			continue
+		}
+		role := CellRoleSecondary
+		if i == 0 {
+			role = CellRolePrimary
+		}
+		reports = append(reports, CellReport{
+			Role:   role,
+			RAT:    RATLTE,
+			ARFCN:  parseInt(stats.StatEARFCN, stats.StatARFCN),
+			Cell:   c.parseCellInfo(stats),
+			Signal: c.parseSignalMetrics(stats),
+		})
 	}
 
-	body, err := io.ReadAll(resp.Body)
+	return reports
+}
+
+// getRadioStatus fetches the radio status from the gateway.
+func (c *ArcadyanClient) getRadioStatus() (*arcadyanRadioStatus, error) {
+	body, err := c.authedGet("/fastmile_radio_status_web_app.cgi")
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, err
 	}
 
 	var radioStatus arcadyanRadioStatus
@@ -144,13 +215,140 @@ func (c *ArcadyanClient) parseCellInfo(stats *arcadyanCellStats) CellInfo {
 	}
 }
 
+// arcadyanDeviceTelemetry represents the JSON response from the device
+// telemetry endpoint.
+type arcadyanDeviceTelemetry struct {
+	Devices []arcadyanDevice `json:"devices"`
+}
+
+type arcadyanDevice struct {
+	MAC       string `json:"mac"`
+	Hostname  string `json:"hostname"`
+	IPv4      string `json:"ipv4"`
+	IPv6      string `json:"ipv6"`
+	Interface string `json:"interface"`
+	RSSI      string `json:"rssi"`
+	LeaseTime string `json:"leaseTimeRemaining"`
+}
+
+// GetDevices retrieves the connected-device inventory from the telemetry
+// endpoint.
+func (c *ArcadyanClient) GetDevices() ([]ConnectedDevice, error) {
+	body, err := c.authedGet("/TMI/v1/network/telemetry?get=devices")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get devices: %w", err)
+	}
+
+	var telemetry arcadyanDeviceTelemetry
+	if err := json.Unmarshal(body, &telemetry); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON response: %w", err)
+	}
+
+	now := time.Now()
+	devices := make([]ConnectedDevice, 0, len(telemetry.Devices))
+	for _, d := range telemetry.Devices {
+		devices = append(devices, ConnectedDevice{
+			MAC:         d.MAC,
+			Hostname:    d.Hostname,
+			IPv4:        d.IPv4,
+			IPv6:        d.IPv6,
+			Interface:   parseDeviceInterface(d.Interface),
+			RSSI:        parseFloat(d.RSSI),
+			LeaseExpiry: now.Add(time.Duration(parseInt(d.LeaseTime)) * time.Second),
+			FirstSeen:   now,
+			LastSeen:    now,
+		})
+	}
+	return devices, nil
+}
+
+// authedGet issues a GET against endpoint with the session token attached,
+// logging in first if necessary and retrying once if the gateway reports
+// the token has expired. See the package-level authedGet for the shared
+// implementation used by every client.
+func (c *ArcadyanClient) authedGet(endpoint string) ([]byte, error) {
+	return authedGet(c.tokens, c.httpClient, c.config.URL, endpoint)
+}
+
+// login performs Arcadyan's nonce-based handshake: a pre-login GET fetches
+// a nonce, which is combined with the configured username/password into
+// hash = sha256(password + sha256(username + nonce)) and POSTed to obtain
+// a session token.
+func (a *arcadyanAuthenticator) login() (string, time.Duration, error) {
+	resp, err := a.httpClient.Get(a.config.URL + "/TMI/v1/auth/nonce")
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to fetch login nonce: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to read login nonce response: %w", err)
+	}
+
+	var nonce arcadyanNonce
+	if err := json.Unmarshal(body, &nonce); err != nil {
+		return "", 0, fmt.Errorf("failed to parse login nonce response: %w", err)
+	}
+
+	hash := hashArcadyanPassword(a.config.Username, a.config.Password, nonce.Nonce)
+
+	reqBody, err := json.Marshal(struct {
+		Username string `json:"username"`
+		Hash     string `json:"hash"`
+	}{Username: a.config.Username, Hash: hash})
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to build login request: %w", err)
+	}
+
+	loginResp, err := a.httpClient.Post(a.config.URL+"/TMI/v1/auth/login", "application/json", bytes.NewReader(reqBody))
+	if err != nil {
+		return "", 0, fmt.Errorf("login request failed: %w", err)
+	}
+	defer loginResp.Body.Close()
+
+	if loginResp.StatusCode != http.StatusOK {
+		return "", 0, fmt.Errorf("login rejected with status %d", loginResp.StatusCode)
+	}
+
+	loginBody, err := io.ReadAll(loginResp.Body)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to read login response: %w", err)
+	}
+
+	var session arcadyanLoginResponse
+	if err := json.Unmarshal(loginBody, &session); err != nil {
+		return "", 0, fmt.Errorf("failed to parse login response: %w", err)
+	}
+	if session.Token == "" {
+		return "", 0, fmt.Errorf("login response did not include a session token")
+	}
+
+	ttl := defaultTokenTTL
+	if session.ExpiresIn > 0 {
+		ttl = time.Duration(session.ExpiresIn) * time.Second
+	}
+	return session.Token, ttl, nil
+}
+
+// hashArcadyanPassword computes hash = sha256(password + sha256(username + nonce)).
+func hashArcadyanPassword(username, password, nonce string) string {
+	inner := sha256.Sum256([]byte(username + nonce))
+	outer := sha256.Sum256([]byte(password + hex.EncodeToString(inner[:])))
+	return hex.EncodeToString(outer[:])
+}
+
 // GetModel returns the gateway model type.
 func (c *ArcadyanClient) GetModel() GatewayModel {
 	return ModelArcadyanKVD21
 }
 
-// Close releases any resources held by the client.
+// Close releases any resources held by the client, stopping the token
+// renewal goroutine if one was started.
 func (c *ArcadyanClient) Close() error {
+	if c.tokens != nil {
+		return c.tokens.Close()
+	}
 	return nil
 }
 
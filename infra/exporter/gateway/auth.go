@@ -0,0 +1,201 @@
+package gateway
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// defaultTokenTTL is used when a gateway's login response does not include
+// an explicit session expiry.
+const defaultTokenTTL = 5 * time.Minute
+
+// authenticator performs a gateway-specific login handshake and reports
+// how long the resulting session token remains valid.
+type authenticator interface {
+	// login authenticates against the gateway and returns a session token
+	// along with its time-to-live.
+	login() (token string, ttl time.Duration, err error)
+}
+
+// tokenManager caches the session token produced by an authenticator and
+// keeps it fresh. Token() logs in on demand the first time it's called;
+// after that, a background goroutine renews the token shortly before it
+// expires so callers never have to wait on a login. If a request still
+// gets a 401 (the renewal raced expiry, or the gateway rejected an early
+// renewal), callers should call Invalidate and retry once, which forces a
+// full re-login.
+type tokenManager struct {
+	auth authenticator
+
+	mu    sync.Mutex
+	token string
+
+	renew  chan time.Duration
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// newTokenManager creates a tokenManager around auth and starts its
+// background renewal loop. The loop is idle until the first login.
+func newTokenManager(auth authenticator) *tokenManager {
+	ctx, cancel := context.WithCancel(context.Background())
+	m := &tokenManager{
+		auth:   auth,
+		renew:  make(chan time.Duration, 1),
+		cancel: cancel,
+	}
+	m.wg.Add(1)
+	go m.renewalLoop(ctx)
+	return m
+}
+
+// Token returns a cached session token, logging in if none has been
+// obtained yet.
+func (m *tokenManager) Token() (string, error) {
+	m.mu.Lock()
+	token := m.token
+	m.mu.Unlock()
+
+	if token != "" {
+		return token, nil
+	}
+	return m.login()
+}
+
+// Invalidate discards the cached token, forcing the next Token() call to
+// perform a full re-login. Callers should invoke this after seeing a 401.
+func (m *tokenManager) Invalidate() {
+	m.mu.Lock()
+	m.token = ""
+	m.mu.Unlock()
+}
+
+// login performs the handshake, caches the resulting token, and (re)arms
+// the background renewal timer for the token's TTL.
+func (m *tokenManager) login() (string, error) {
+	token, ttl, err := m.auth.login()
+	if err != nil {
+		return "", fmt.Errorf("login failed: %w", err)
+	}
+
+	m.mu.Lock()
+	m.token = token
+	m.mu.Unlock()
+
+	select {
+	case m.renew <- ttl:
+	default:
+	}
+	return token, nil
+}
+
+// renewalLoop waits for a TTL to arm against, then re-logs-in shortly
+// before that TTL elapses, rearming itself with the new TTL each time.
+// A failed renewal is ignored (renew-behavior-ignore-errors): the stale
+// token is left in place, and the next request that hits a 401 triggers
+// Invalidate and a full re-login instead.
+func (m *tokenManager) renewalLoop(ctx context.Context) {
+	defer m.wg.Done()
+
+	timer := time.NewTimer(time.Hour)
+	timer.Stop()
+	armed := false
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case ttl := <-m.renew:
+			if armed && !timer.Stop() {
+				<-timer.C
+			}
+			timer.Reset(jitteredRenewAfter(ttl))
+			armed = true
+		case <-timer.C:
+			armed = false
+			if _, err := m.login(); err != nil {
+				continue
+			}
+		}
+	}
+}
+
+// jitteredRenewAfter returns the delay before a proactive renewal: roughly
+// 80% of the TTL, plus up to 10% jitter so multiple clients sharing a
+// gateway don't all renew in lockstep.
+func jitteredRenewAfter(ttl time.Duration) time.Duration {
+	renewAt := time.Duration(float64(ttl) * 0.8)
+	jitter := time.Duration(rand.Int63n(int64(ttl)/10 + 1))
+	return renewAt + jitter
+}
+
+// Close stops the background renewal goroutine.
+func (m *tokenManager) Close() error {
+	m.cancel()
+	m.wg.Wait()
+	return nil
+}
+
+// authedGet issues a GET against baseURL+endpoint using httpClient,
+// attaching the session token cached by tokens, logging in first if
+// necessary and retrying once if the gateway reports the token has
+// expired. tokens may be nil, in which case the request is made without
+// an Authorization header. This is shared by every client in this package
+// so the 401-retry logic only needs to be fixed in one place.
+func authedGet(tokens *tokenManager, httpClient *http.Client, baseURL, endpoint string) ([]byte, error) {
+	body, status, err := doGet(tokens, httpClient, baseURL, endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	if status == http.StatusUnauthorized && tokens != nil {
+		tokens.Invalidate()
+		body, status, err = doGet(tokens, httpClient, baseURL, endpoint)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", status)
+	}
+	return body, nil
+}
+
+// doGet performs a single GET against baseURL+endpoint, attaching the
+// session token from tokens if credentials are configured.
+func doGet(tokens *tokenManager, httpClient *http.Client, baseURL, endpoint string) ([]byte, int, error) {
+	var token string
+	if tokens != nil {
+		var err error
+		token, err = tokens.Token()
+		if err != nil {
+			return nil, 0, fmt.Errorf("login failed: %w", err)
+		}
+	}
+
+	req, err := http.NewRequest(http.MethodGet, baseURL+endpoint, nil)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to build request: %w", err)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("HTTP request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, resp.StatusCode, fmt.Errorf("failed to read response body: %w", err)
+	}
+	return body, resp.StatusCode, nil
+}
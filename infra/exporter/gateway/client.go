@@ -1,10 +1,14 @@
 package gateway
 
 import (
+	"context"
 	"crypto/tls"
 	"fmt"
 	"net/http"
+	"sort"
 	"time"
+
+	"golang.org/x/sync/errgroup"
 )
 
 // ClientConfig contains configuration for connecting to a gateway.
@@ -50,53 +54,82 @@ func NewClient(cfg ClientConfig) (GatewayClient, error) {
 		},
 	}
 
-	// If model is specified, create the appropriate client
-	switch cfg.Model {
+	if cfg.Model == ModelUnknown {
+		return autoDetectClient(cfg, httpClient)
+	}
+	return newClientForModel(cfg.Model, cfg, httpClient)
+}
+
+// newClientForModel constructs the client for a known model.
+func newClientForModel(model GatewayModel, cfg ClientConfig, httpClient *http.Client) (GatewayClient, error) {
+	switch model {
 	case ModelArcadyanKVD21:
 		return NewArcadyanClient(cfg, httpClient)
 	case ModelNokia:
 		return NewNokiaClient(cfg, httpClient)
 	case ModelSagemcom:
 		return NewSagemcomClient(cfg, httpClient)
-	case ModelUnknown:
-		// Auto-detect the gateway model
-		return autoDetectClient(cfg, httpClient)
 	default:
-		return nil, fmt.Errorf("unsupported gateway model: %s", cfg.Model)
+		return nil, fmt.Errorf("unsupported gateway model: %s", model)
 	}
 }
 
-// autoDetectClient attempts to detect the gateway type and return the appropriate client.
+// autoDetectClient identifies the gateway model by firing a lightweight
+// fingerprint probe at every registered vendor concurrently (see
+// Fingerprints), instead of trying each vendor's full GetStatus in turn -
+// which would delay detection by up to len(Fingerprints) x Timeout against
+// a gateway that hangs. It constructs a client for whichever vendor scored
+// highest and confirms the guess with a real GetStatus, falling back to
+// the next-highest scorer if that fails.
 func autoDetectClient(cfg ClientConfig, httpClient *http.Client) (GatewayClient, error) {
-	// Try Arcadyan KVD21 first (most common T-Mobile gateway)
-	arcadyanClient, err := NewArcadyanClient(cfg, httpClient)
-	if err == nil {
-		_, err = arcadyanClient.GetStatus()
-		if err == nil {
-			return arcadyanClient, nil
-		}
-		arcadyanClient.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Timeout)
+	defer cancel()
+
+	g, gctx := errgroup.WithContext(ctx)
+	scores := make([]int, len(Fingerprints))
+
+	for i, fp := range Fingerprints {
+		i, fp := i, fp
+		g.Go(func() error {
+			score, err := probeFingerprint(gctx, cfg, httpClient, fp)
+			if err != nil {
+				// A failed probe (timeout, connection refused, etc.) just
+				// means this vendor scores 0, not that detection fails.
+				return nil
+			}
+			scores[i] = score
+			return nil
+		})
 	}
+	g.Wait()
 
-	// Try Nokia
-	nokiaClient, err := NewNokiaClient(cfg, httpClient)
-	if err == nil {
-		_, err = nokiaClient.GetStatus()
-		if err == nil {
-			return nokiaClient, nil
-		}
-		nokiaClient.Close()
+	ranked := make([]int, len(Fingerprints))
+	for i := range ranked {
+		ranked[i] = i
 	}
+	sort.Slice(ranked, func(a, b int) bool { return scores[ranked[a]] > scores[ranked[b]] })
 
-	// Try Sagemcom
-	sagemcomClient, err := NewSagemcomClient(cfg, httpClient)
-	if err == nil {
-		_, err = sagemcomClient.GetStatus()
-		if err == nil {
-			return sagemcomClient, nil
+	var lastErr error
+	for _, i := range ranked {
+		if scores[i] <= 0 {
+			break
 		}
-		sagemcomClient.Close()
+
+		client, err := newClientForModel(Fingerprints[i].Model, cfg, httpClient)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if _, err := client.GetStatus(); err != nil {
+			lastErr = fmt.Errorf("detected %s but failed to confirm: %w", Fingerprints[i].Model, err)
+			client.Close()
+			continue
+		}
+		return client, nil
 	}
 
+	if lastErr != nil {
+		return nil, fmt.Errorf("could not auto-detect gateway model at %s: %w", cfg.URL, lastErr)
+	}
 	return nil, fmt.Errorf("could not auto-detect gateway model at %s", cfg.URL)
 }
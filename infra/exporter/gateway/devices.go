@@ -0,0 +1,54 @@
+package gateway
+
+import "strings"
+
+// DeviceDelta reports the devices that newly joined and the devices that
+// departed between two ConnectedDevice inventories.
+type DeviceDelta struct {
+	Joined   []ConnectedDevice
+	Departed []ConnectedDevice
+}
+
+// DiffDevices compares the device inventory from a previous poll against
+// the current one and reports which devices (by MAC) are new and which
+// are no longer present. GetDevices itself is stateless, so this is the
+// intended way for callers to build presence-monitoring on top of it.
+func DiffDevices(previous, current []ConnectedDevice) DeviceDelta {
+	prevByMAC := make(map[string]struct{}, len(previous))
+	for _, d := range previous {
+		prevByMAC[d.MAC] = struct{}{}
+	}
+	curByMAC := make(map[string]struct{}, len(current))
+	for _, d := range current {
+		curByMAC[d.MAC] = struct{}{}
+	}
+
+	var delta DeviceDelta
+	for _, d := range current {
+		if _, ok := prevByMAC[d.MAC]; !ok {
+			delta.Joined = append(delta.Joined, d)
+		}
+	}
+	for _, d := range previous {
+		if _, ok := curByMAC[d.MAC]; !ok {
+			delta.Departed = append(delta.Departed, d)
+		}
+	}
+	return delta
+}
+
+// parseDeviceInterface normalizes a gateway-reported interface/band string
+// (e.g. "eth0", "5GHz", "wl1") to a DeviceInterface.
+func parseDeviceInterface(raw string) DeviceInterface {
+	raw = strings.ToLower(strings.TrimSpace(raw))
+	switch {
+	case strings.Contains(raw, "6g"):
+		return DeviceInterfaceWifi6
+	case strings.Contains(raw, "5g"):
+		return DeviceInterfaceWifi5
+	case strings.Contains(raw, "2.4g"), strings.Contains(raw, "2g"):
+		return DeviceInterfaceWifi24
+	default:
+		return DeviceInterfaceLAN
+	}
+}
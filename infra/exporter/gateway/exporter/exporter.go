@@ -0,0 +1,238 @@
+// Package exporter publishes a single gateway.GatewayClient's status as
+// Prometheus metrics, a JSON snapshot, and a Server-Sent Events stream, so
+// the 5g-inspector CLI can be wired into Grafana/alerting instead of only
+// being used one-shot or as a TUI.
+//
+// It is deliberately scoped to one client; multi-gateway Prometheus
+// exporting with config-file fan-out already exists in package metrics.
+package exporter
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/tmobile-dashboard/exporter/gateway"
+)
+
+// defaultPollInterval is used when Config doesn't specify one.
+const defaultPollInterval = 5 * time.Second
+
+// Config configures an Exporter.
+type Config struct {
+	// PollInterval is how often the gateway is polled in the background.
+	// Defaults to defaultPollInterval if zero.
+	PollInterval time.Duration
+}
+
+// Exporter wraps a gateway.GatewayClient with background polling and
+// serves the result as Prometheus metrics (via Collect), a JSON snapshot
+// (Snapshot), and a live stream of updates (Subscribe). Call Start before
+// registering it with Prometheus or serving HTTP.
+type Exporter struct {
+	client   gateway.GatewayClient
+	interval time.Duration
+
+	cacheMu    sync.RWMutex
+	status     *gateway.GatewayStatus
+	err        error
+	lastScrape time.Time
+
+	subMu sync.Mutex
+	subs  map[chan gateway.GatewayStatus]struct{}
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	// Signal metrics, labeled per reported cell (serving/secondary).
+	rsrpDesc  *prometheus.Desc
+	rsrqDesc  *prometheus.Desc
+	sinrDesc  *prometheus.Desc
+	rssiDesc  *prometheus.Desc
+	arfcnDesc *prometheus.Desc
+
+	// Aggregated carrier-aggregation signal (status.PrimarySignal).
+	primaryRSRPDesc *prometheus.Desc
+	primaryRSRQDesc *prometheus.Desc
+	primarySINRDesc *prometheus.Desc
+	primaryRSSIDesc *prometheus.Desc
+
+	// SIM and connection info metrics.
+	simActiveDesc  *prometheus.Desc
+	connectionDesc *prometheus.Desc
+
+	// Scrape health.
+	upDesc         *prometheus.Desc
+	lastScrapeDesc *prometheus.Desc
+}
+
+// New creates an Exporter for client. It does not poll anything until
+// Start is called.
+func New(client gateway.GatewayClient, cfg Config) *Exporter {
+	interval := cfg.PollInterval
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+
+	cellLabels := []string{"rat", "role", "band", "cell"}
+	simLabels := []string{"slot", "iccid"}
+	connectionLabels := []string{"type", "status"}
+
+	return &Exporter{
+		client:   client,
+		interval: interval,
+		subs:     make(map[chan gateway.GatewayStatus]struct{}),
+
+		rsrpDesc:  prometheus.NewDesc("fivegi_rsrp_dbm", "Reference Signal Received Power in dBm, per reported cell", cellLabels, nil),
+		rsrqDesc:  prometheus.NewDesc("fivegi_rsrq_db", "Reference Signal Received Quality in dB, per reported cell", cellLabels, nil),
+		sinrDesc:  prometheus.NewDesc("fivegi_sinr_db", "Signal to Interference Noise Ratio in dB, per reported cell", cellLabels, nil),
+		rssiDesc:  prometheus.NewDesc("fivegi_rssi_dbm", "Received Signal Strength Indicator in dBm, per reported cell", cellLabels, nil),
+		arfcnDesc: prometheus.NewDesc("fivegi_arfcn", "Absolute radio frequency channel number, per reported cell", cellLabels, nil),
+
+		primaryRSRPDesc: prometheus.NewDesc("fivegi_primary_rsrp_dbm", "RSRP combined across the primary cell and any carrier-aggregation secondaries", nil, nil),
+		primaryRSRQDesc: prometheus.NewDesc("fivegi_primary_rsrq_db", "RSRQ averaged across the primary cell and any carrier-aggregation secondaries", nil, nil),
+		primarySINRDesc: prometheus.NewDesc("fivegi_primary_sinr_db", "SINR averaged across the primary cell and any carrier-aggregation secondaries", nil, nil),
+		primaryRSSIDesc: prometheus.NewDesc("fivegi_primary_rssi_dbm", "RSSI averaged across the primary cell and any carrier-aggregation secondaries", nil, nil),
+
+		simActiveDesc:  prometheus.NewDesc("fivegi_sim_active", "Whether this SIM slot is the one currently in use", simLabels, nil),
+		connectionDesc: prometheus.NewDesc("fivegi_connection_info", "Connection type and status; the series itself (always 1) carries the info in its labels", connectionLabels, nil),
+
+		upDesc:         prometheus.NewDesc("fivegi_gateway_up", "Whether the gateway was reachable on the last background poll", nil, nil),
+		lastScrapeDesc: prometheus.NewDesc("fivegi_last_scrape_timestamp_seconds", "Unix timestamp of the last background poll", nil, nil),
+	}
+}
+
+// Start begins polling the gateway in the background until ctx is
+// cancelled or Close is called.
+func (e *Exporter) Start(ctx context.Context) {
+	ctx, e.cancel = context.WithCancel(ctx)
+
+	e.wg.Add(1)
+	go func() {
+		defer e.wg.Done()
+		for status := range gateway.Stream(ctx, e.client, e.interval) {
+			e.observe(status)
+		}
+	}()
+}
+
+// observe caches the latest status and fans it out to every Subscribe channel.
+func (e *Exporter) observe(status gateway.GatewayStatus) {
+	e.cacheMu.Lock()
+	e.status = &status
+	e.err = nil
+	e.lastScrape = time.Now()
+	e.cacheMu.Unlock()
+
+	e.subMu.Lock()
+	defer e.subMu.Unlock()
+	for sub := range e.subs {
+		select {
+		case sub <- status:
+		default:
+			// Slow subscriber; drop the update rather than block polling.
+		}
+	}
+}
+
+// Snapshot returns the most recently cached status.
+func (e *Exporter) Snapshot() (status *gateway.GatewayStatus, lastScrape time.Time) {
+	e.cacheMu.RLock()
+	defer e.cacheMu.RUnlock()
+	return e.status, e.lastScrape
+}
+
+// Subscribe registers a channel that receives every polled status until
+// unsubscribe is called. The channel is buffered; a subscriber that falls
+// behind misses updates rather than stalling the poller.
+func (e *Exporter) Subscribe() (ch <-chan gateway.GatewayStatus, unsubscribe func()) {
+	sub := make(chan gateway.GatewayStatus, 4)
+
+	e.subMu.Lock()
+	e.subs[sub] = struct{}{}
+	e.subMu.Unlock()
+
+	return sub, func() {
+		e.subMu.Lock()
+		delete(e.subs, sub)
+		e.subMu.Unlock()
+		close(sub)
+	}
+}
+
+// Close stops background polling.
+func (e *Exporter) Close() error {
+	if e.cancel != nil {
+		e.cancel()
+	}
+	e.wg.Wait()
+	return nil
+}
+
+// Describe implements prometheus.Collector.
+func (e *Exporter) Describe(ch chan<- *prometheus.Desc) {
+	ch <- e.rsrpDesc
+	ch <- e.rsrqDesc
+	ch <- e.sinrDesc
+	ch <- e.rssiDesc
+	ch <- e.arfcnDesc
+	ch <- e.primaryRSRPDesc
+	ch <- e.primaryRSRQDesc
+	ch <- e.primarySINRDesc
+	ch <- e.primaryRSSIDesc
+	ch <- e.simActiveDesc
+	ch <- e.connectionDesc
+	ch <- e.upDesc
+	ch <- e.lastScrapeDesc
+}
+
+// Collect implements prometheus.Collector, serving the most recently
+// cached poll rather than hitting the gateway inline.
+func (e *Exporter) Collect(ch chan<- prometheus.Metric) {
+	status, lastScrape := e.Snapshot()
+
+	ch <- prometheus.MustNewConstMetric(e.lastScrapeDesc, prometheus.GaugeValue, float64(lastScrape.Unix()))
+
+	if status == nil {
+		ch <- prometheus.MustNewConstMetric(e.upDesc, prometheus.GaugeValue, 0)
+		return
+	}
+	ch <- prometheus.MustNewConstMetric(e.upDesc, prometheus.GaugeValue, 1)
+
+	for _, cell := range status.Cells {
+		labels := []string{string(cell.RAT), string(cell.Role), formatBand(cell.RAT, cell.Cell.Band), strconv.FormatInt(cell.Cell.PCI, 10)}
+		ch <- prometheus.MustNewConstMetric(e.rsrpDesc, prometheus.GaugeValue, cell.Signal.RSRP, labels...)
+		ch <- prometheus.MustNewConstMetric(e.rsrqDesc, prometheus.GaugeValue, cell.Signal.RSRQ, labels...)
+		ch <- prometheus.MustNewConstMetric(e.sinrDesc, prometheus.GaugeValue, cell.Signal.SINR, labels...)
+		ch <- prometheus.MustNewConstMetric(e.rssiDesc, prometheus.GaugeValue, cell.Signal.RSSI, labels...)
+		ch <- prometheus.MustNewConstMetric(e.arfcnDesc, prometheus.GaugeValue, float64(cell.ARFCN), labels...)
+	}
+
+	ch <- prometheus.MustNewConstMetric(e.primaryRSRPDesc, prometheus.GaugeValue, status.PrimarySignal.RSRP)
+	ch <- prometheus.MustNewConstMetric(e.primaryRSRQDesc, prometheus.GaugeValue, status.PrimarySignal.RSRQ)
+	ch <- prometheus.MustNewConstMetric(e.primarySINRDesc, prometheus.GaugeValue, status.PrimarySignal.SINR)
+	ch <- prometheus.MustNewConstMetric(e.primaryRSSIDesc, prometheus.GaugeValue, status.PrimarySignal.RSSI)
+
+	for _, sim := range status.SIMs {
+		active := 0.0
+		if sim.Active {
+			active = 1.0
+		}
+		ch <- prometheus.MustNewConstMetric(e.simActiveDesc, prometheus.GaugeValue, active, strconv.Itoa(sim.SlotIndex), sim.ICCID)
+	}
+
+	ch <- prometheus.MustNewConstMetric(e.connectionDesc, prometheus.GaugeValue, 1, status.Connection.Type, status.Connection.Status)
+}
+
+// formatBand renders a band number with the conventional NR/LTE prefix
+// ("n41", "b66"), matching how gateways themselves label bands.
+func formatBand(rat gateway.RAT, band int64) string {
+	prefix := "b"
+	if rat == gateway.RATNR {
+		prefix = "n"
+	}
+	return prefix + strconv.FormatInt(band, 10)
+}
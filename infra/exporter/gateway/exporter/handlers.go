@@ -0,0 +1,70 @@
+package exporter
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// StatusHandler serves the most recently polled GatewayStatus as a single
+// JSON snapshot. Responds 503 until the first poll completes.
+func (e *Exporter) StatusHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		status, _ := e.Snapshot()
+		if status == nil {
+			http.Error(w, "no gateway status polled yet", http.StatusServiceUnavailable)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		enc.Encode(status)
+	}
+}
+
+// EventsHandler serves polled GatewayStatus updates as Server-Sent Events,
+// one "data:" line of JSON per poll, for browser/Grafana live-data panels
+// that want push updates instead of scraping /status.json.
+func (e *Exporter) EventsHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+
+		sub, unsubscribe := e.Subscribe()
+		defer unsubscribe()
+
+		if status, _ := e.Snapshot(); status != nil {
+			writeEvent(w, status)
+			flusher.Flush()
+		}
+
+		for {
+			select {
+			case <-r.Context().Done():
+				return
+			case status, ok := <-sub:
+				if !ok {
+					return
+				}
+				writeEvent(w, &status)
+				flusher.Flush()
+			}
+		}
+	}
+}
+
+func writeEvent(w http.ResponseWriter, status interface{}) {
+	body, err := json.Marshal(status)
+	if err != nil {
+		return
+	}
+	fmt.Fprintf(w, "data: %s\n\n", body)
+}
@@ -0,0 +1,104 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// FingerprintProbe describes a lightweight, unauthenticated HTTP request
+// that distinguishes one gateway vendor from another, and how to score a
+// response against it. autoDetectClient fires every registered probe
+// concurrently and picks whichever vendor scores highest, so identifying a
+// gateway doesn't cost a full GetStatus attempt per vendor.
+type FingerprintProbe struct {
+	// Model is the gateway model this probe identifies.
+	Model GatewayModel
+
+	// Method is the HTTP method to probe with; HEAD where a vendor's
+	// response can be told apart from headers/status alone, GET otherwise.
+	Method string
+
+	// Path is the HTTP path probed, relative to ClientConfig.URL.
+	Path string
+
+	// Score inspects the probe response and returns how confidently it
+	// matches this vendor. 0 means no match; higher is more confident.
+	Score func(resp *http.Response, body []byte) int
+}
+
+// Fingerprints is the registry of known vendor probes consulted by
+// autoDetectClient. Append to it to support detecting additional gateway
+// vendors without editing NewClient.
+var Fingerprints = []FingerprintProbe{
+	{
+		// Nokia's FastMile cgi endpoint serves radio status as JSON
+		// without requiring auth for an unauthenticated probe GET.
+		Model:  ModelNokia,
+		Method: http.MethodGet,
+		Path:   "/fastmile_radio_status_web_app.cgi",
+		Score: func(resp *http.Response, body []byte) int {
+			if resp.StatusCode == http.StatusOK && json.Valid(body) {
+				return 10
+			}
+			return 0
+		},
+	},
+	{
+		// Arcadyan's TMI API challenges unauthenticated requests with a
+		// 401 naming the "TMI" realm.
+		Model:  ModelArcadyanKVD21,
+		Method: http.MethodGet,
+		Path:   "/TMI/v1/network/telemetry",
+		Score: func(resp *http.Response, body []byte) int {
+			if resp.StatusCode != http.StatusUnauthorized {
+				return 0
+			}
+			if strings.Contains(resp.Header.Get("Www-Authenticate"), "TMI") {
+				return 10
+			}
+			return 5
+		},
+	},
+	{
+		// Sagemcom gateways identify themselves in the Server header and
+		// set a distinctive session cookie on the landing page.
+		Model:  ModelSagemcom,
+		Method: http.MethodHead,
+		Path:   "/",
+		Score: func(resp *http.Response, body []byte) int {
+			if strings.Contains(strings.ToLower(resp.Header.Get("Server")), "sagemcom") {
+				return 10
+			}
+			for _, c := range resp.Cookies() {
+				if strings.EqualFold(c.Name, "sessionToken") {
+					return 5
+				}
+			}
+			return 0
+		},
+	},
+}
+
+// probeFingerprint issues fp's probe request and scores the response.
+func probeFingerprint(ctx context.Context, cfg ClientConfig, httpClient *http.Client, fp FingerprintProbe) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, fp.Method, cfg.URL+fp.Path, nil)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if err != nil {
+		return 0, err
+	}
+
+	return fp.Score(resp, body), nil
+}
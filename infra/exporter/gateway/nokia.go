@@ -1,27 +1,78 @@
 package gateway
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
+	"time"
 )
 
-// NokiaClient implements GatewayClient for Nokia FastMile 5G gateways.
-// Tested with Nokia FastMile 5G Gateway (model 5G21).
+// NokiaClient implements GatewayClient for Nokia FastMile 5G gateways
+// (nicknamed the "Trashcan" for its shape). Tested with Nokia FastMile 5G
+// Gateway (model 5G21).
+//
+// Nokia's firmware gates the radio status endpoints behind a hashed-password
+// login that returns a session token; see nokiaAuthenticator.login() for the
+// handshake. The resulting token is cached and transparently renewed by a
+// tokenManager, so callers of getRadioStatus never see a re-auth.
 type NokiaClient struct {
 	config     ClientConfig
 	httpClient *http.Client
+
+	// tokens is nil when no credentials are configured, in which case
+	// requests are made without an Authorization header.
+	tokens *tokenManager
+}
+
+// nokiaAuthenticator implements authenticator for Nokia's hashed-password
+// login handshake.
+type nokiaAuthenticator struct {
+	config     ClientConfig
+	httpClient *http.Client
+}
+
+// nokiaLoginSalt is returned by a pre-login GET and seeds the password hash.
+type nokiaLoginSalt struct {
+	Salt    string `json:"salt"`
+	SaltWeb string `json:"saltwebui"`
+}
+
+// nokiaLoginResponse carries the session token issued after a successful login.
+type nokiaLoginResponse struct {
+	Token string `json:"token"`
+	Sid   string `json:"sid"`
 }
 
 // nokiaRadioStatus represents the JSON response from Nokia's radio status endpoint.
 type nokiaRadioStatus struct {
-	// 5G NR stats
+	// 5G NR stats for the anchor/primary cell.
 	Cell5G nokiaCellStats `json:"cell_5G_stats"`
+	// Secondary 5G NR component carriers for carrier aggregation. Newer
+	// firmware reports these under cell_5G_stats_sec; older firmware uses
+	// the more generic ca_stats instead (see secondaryComponentCarriers).
+	Cell5GSec []nokiaCellStats `json:"cell_5G_stats_sec"`
+	CAStats   []nokiaCellStats `json:"ca_stats"`
 	// LTE stats
 	CellLTE nokiaCellStats `json:"cell_LTE_stats"`
+
+	// Per-SIM status, for multi-SIM gateways. nil when the slot is unused
+	// or the firmware doesn't report multi-SIM status.
+	SIM1 *nokiaSIMStats `json:"sim1_stats"`
+	SIM2 *nokiaSIMStats `json:"sim2_stats"`
+}
+
+// nokiaSIMStats represents a single SIM slot's status.
+type nokiaSIMStats struct {
+	ICCID  interface{} `json:"iccid"`
+	IMSI   interface{} `json:"imsi"`
+	Active interface{} `json:"active"`
 }
 
 type nokiaCellStats struct {
@@ -54,10 +105,14 @@ func NewNokiaClient(cfg ClientConfig, httpClient *http.Client) (*NokiaClient, er
 	if httpClient == nil {
 		return nil, fmt.Errorf("httpClient is required")
 	}
-	return &NokiaClient{
+	c := &NokiaClient{
 		config:     cfg,
 		httpClient: httpClient,
-	}, nil
+	}
+	if cfg.Username != "" {
+		c.tokens = newTokenManager(&nokiaAuthenticator{config: cfg, httpClient: httpClient})
+	}
+	return c, nil
 }
 
 // GetStatus retrieves the current gateway status.
@@ -84,11 +139,45 @@ func (c *NokiaClient) GetStatus() (*GatewayStatus, error) {
 		cellStats = &radioStatus.CellLTE
 	}
 
+	secondaries := c.secondaryComponentCarriers(radioStatus)
+
 	if cellStats != nil {
 		status.Signal = c.parseSignalMetrics(cellStats)
 		status.Cell = c.parseCellInfo(cellStats)
+
+		rat := RATLTE
+		if connectionType == "5G" {
+			rat = RATNR
+		}
+		status.Cells = []CellReport{{
+			Role:   CellRolePrimary,
+			RAT:    rat,
+			ARFCN:  int64(c.parseNumeric(cellStats.EARFCN)),
+			Cell:   status.Cell,
+			Signal: status.Signal,
+		}}
+
+		for i := range secondaries {
+			sec := &secondaries[i]
+			status.Cells = append(status.Cells, CellReport{
+				Role:   CellRoleSecondary,
+				RAT:    RATNR,
+				ARFCN:  int64(c.parseNumeric(sec.EARFCN)),
+				Cell:   c.parseCellInfo(sec),
+				Signal: c.parseSignalMetrics(sec),
+			})
+		}
+
+		status.PrimarySignal = c.aggregateSignal(cellStats, secondaries)
 	}
 
+	status.SecondaryCells = make([]CellInfo, len(secondaries))
+	for i := range secondaries {
+		status.SecondaryCells[i] = c.parseCellInfo(&secondaries[i])
+	}
+
+	status.SIMs = c.parseSIMs(radioStatus)
+
 	status.Connection = ConnectionInfo{
 		Type:   connectionType,
 		Status: "connected",
@@ -97,6 +186,96 @@ func (c *NokiaClient) GetStatus() (*GatewayStatus, error) {
 	return status, nil
 }
 
+// secondaryComponentCarriers returns the secondary 5G component carriers
+// reported for carrier aggregation, preferring the dedicated
+// cell_5G_stats_sec key and falling back to the more generic ca_stats that
+// older firmware uses instead.
+func (c *NokiaClient) secondaryComponentCarriers(rs *nokiaRadioStatus) []nokiaCellStats {
+	if len(rs.Cell5GSec) > 0 {
+		return rs.Cell5GSec
+	}
+	return rs.CAStats
+}
+
+// aggregateSignal combines signal quality across the primary cell and
+// every secondary component carrier, so callers can see how much carrier
+// aggregation is actually improving the connection rather than just the
+// anchor cell's own numbers. RSRP is combined as linear power (the
+// physically meaningful way to sum received power across carriers) and
+// converted back to dBm; RSRQ/RSSI/SINR are averaged across carriers.
+func (c *NokiaClient) aggregateSignal(primary *nokiaCellStats, secondaries []nokiaCellStats) SignalMetrics {
+	if primary == nil {
+		return SignalMetrics{}
+	}
+
+	all := append([]nokiaCellStats{*primary}, secondaries...)
+
+	var rsrpMilliwatts, rsrqSum, rssiSum, sinrSum float64
+	for _, cc := range all {
+		rsrpMilliwatts += math.Pow(10, c.parseNumeric(cc.RSRP)/10)
+		rsrqSum += c.parseNumeric(cc.RSRQ)
+		rssiSum += c.parseNumeric(cc.RSSI)
+
+		sinr := c.parseNumeric(cc.SINR)
+		if sinr == 0 {
+			sinr = c.parseNumeric(cc.SNR)
+		}
+		sinrSum += sinr
+	}
+
+	n := float64(len(all))
+	return SignalMetrics{
+		RSRP: 10 * math.Log10(rsrpMilliwatts),
+		RSRQ: rsrqSum / n,
+		RSSI: rssiSum / n,
+		SINR: sinrSum / n,
+	}
+}
+
+// parseSIMs extracts the configured SIM slots' status, if the firmware
+// reported any.
+func (c *NokiaClient) parseSIMs(rs *nokiaRadioStatus) []SIMInfo {
+	var sims []SIMInfo
+	if rs.SIM1 != nil {
+		sims = append(sims, c.parseSIM(rs.SIM1, 1))
+	}
+	if rs.SIM2 != nil {
+		sims = append(sims, c.parseSIM(rs.SIM2, 2))
+	}
+	return sims
+}
+
+// parseSIM converts a single SIM slot's raw stats to SIMInfo, masking the
+// IMSI so subscriber identity isn't carried into metrics/logs.
+func (c *NokiaClient) parseSIM(s *nokiaSIMStats, slot int) SIMInfo {
+	return SIMInfo{
+		ICCID:      c.parseString(s.ICCID),
+		IMSIMasked: maskIMSI(c.parseString(s.IMSI)),
+		SlotIndex:  slot,
+		Active:     c.parseBool(s.Active),
+	}
+}
+
+// parseString handles string-like fields that may come from the API as a
+// nil interface{} (missing or JSON null), rather than stringifying nil
+// into the literal "<nil>".
+func (c *NokiaClient) parseString(v interface{}) string {
+	if v == nil {
+		return ""
+	}
+	return fmt.Sprintf("%v", v)
+}
+
+// maskIMSI redacts everything past the first 6 digits (the MCC+MNC, which
+// identifies the home network) of an IMSI.
+func maskIMSI(imsi string) string {
+	imsi = strings.TrimSpace(imsi)
+	if len(imsi) <= 6 {
+		return strings.Repeat("*", len(imsi))
+	}
+	return imsi[:6] + strings.Repeat("*", len(imsi)-6)
+}
+
 // hasValidSignal checks if the cell stats contain valid signal data.
 func (c *NokiaClient) hasValidSignal(stats *nokiaCellStats) bool {
 	rsrp := c.parseNumeric(stats.RSRP)
@@ -114,24 +293,11 @@ func (c *NokiaClient) getRadioStatus() (*nokiaRadioStatus, error) {
 
 	var lastErr error
 	for _, endpoint := range endpoints {
-		url := fmt.Sprintf("%s%s", c.config.URL, endpoint)
-		resp, err := c.httpClient.Get(url)
+		body, err := c.authedGet(endpoint)
 		if err != nil {
 			lastErr = err
 			continue
 		}
-		defer resp.Body.Close()
-
-		if resp.StatusCode != http.StatusOK {
-			lastErr = fmt.Errorf("unexpected status code: %d", resp.StatusCode)
-			continue
-		}
-
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			lastErr = fmt.Errorf("failed to read response body: %w", err)
-			continue
-		}
 
 		var radioStatus nokiaRadioStatus
 		if err := json.Unmarshal(body, &radioStatus); err != nil {
@@ -153,6 +319,80 @@ func (c *NokiaClient) getRadioStatus() (*nokiaRadioStatus, error) {
 	return nil, fmt.Errorf("could not connect to Nokia gateway")
 }
 
+// authedGet issues a GET against endpoint with the session token attached,
+// logging in first if necessary and retrying once if the gateway reports
+// the token has expired. See the package-level authedGet for the shared
+// implementation used by every client.
+func (c *NokiaClient) authedGet(endpoint string) ([]byte, error) {
+	return authedGet(c.tokens, c.httpClient, c.config.URL, endpoint)
+}
+
+// login performs the Nokia hashed-password handshake: a pre-login GET
+// fetches a salt, which is combined with the configured username/password
+// into pass_hash = sha256(password + sha256(username + salt)) and POSTed
+// to obtain a session token. Nokia's login response carries no explicit
+// expiry, so the token is assumed valid for defaultTokenTTL.
+func (a *nokiaAuthenticator) login() (string, time.Duration, error) {
+	resp, err := a.httpClient.Get(a.config.URL + "/login_web_app.cgi")
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to fetch login salt: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to read login salt response: %w", err)
+	}
+
+	var salt nokiaLoginSalt
+	if err := json.Unmarshal(body, &salt); err != nil {
+		return "", 0, fmt.Errorf("failed to parse login salt response: %w", err)
+	}
+
+	passHash := hashNokiaPassword(a.config.Username, a.config.Password, salt.Salt)
+
+	form := url.Values{
+		"userName":  {a.config.Username},
+		"pass_hash": {passHash},
+	}
+	loginResp, err := a.httpClient.PostForm(a.config.URL+"/login_web_app.cgi", form)
+	if err != nil {
+		return "", 0, fmt.Errorf("login request failed: %w", err)
+	}
+	defer loginResp.Body.Close()
+
+	if loginResp.StatusCode != http.StatusOK {
+		return "", 0, fmt.Errorf("login rejected with status %d", loginResp.StatusCode)
+	}
+
+	loginBody, err := io.ReadAll(loginResp.Body)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to read login response: %w", err)
+	}
+
+	var session nokiaLoginResponse
+	if err := json.Unmarshal(loginBody, &session); err != nil {
+		return "", 0, fmt.Errorf("failed to parse login response: %w", err)
+	}
+
+	token := session.Token
+	if token == "" {
+		token = session.Sid
+	}
+	if token == "" {
+		return "", 0, fmt.Errorf("login response did not include a session token")
+	}
+
+	return token, defaultTokenTTL, nil
+}
+
+// hashNokiaPassword computes pass_hash = sha256(password + sha256(username + salt)).
+func hashNokiaPassword(username, password, salt string) string {
+	inner := sha256.Sum256([]byte(username + salt))
+	outer := sha256.Sum256([]byte(password + hex.EncodeToString(inner[:])))
+	return hex.EncodeToString(outer[:])
+}
+
 // parseAlternateFormat handles different JSON response formats from Nokia gateways.
 func (c *NokiaClient) parseAlternateFormat(data map[string]interface{}) nokiaRadioStatus {
 	var result nokiaRadioStatus
@@ -171,9 +411,49 @@ func (c *NokiaClient) parseAlternateFormat(data map[string]interface{}) nokiaRad
 		result.CellLTE = c.mapToNokiaCellStats(cellLTE)
 	}
 
+	// Try to extract secondary component carriers
+	if sec, ok := data["cell_5G_stats_sec"].([]interface{}); ok {
+		result.Cell5GSec = c.mapToNokiaCellStatsSlice(sec)
+	}
+	if ca, ok := data["ca_stats"].([]interface{}); ok {
+		result.CAStats = c.mapToNokiaCellStatsSlice(ca)
+	}
+
+	// Try to extract per-SIM status
+	if sim1, ok := data["sim1_stats"].(map[string]interface{}); ok {
+		s := mapToNokiaSIMStats(sim1)
+		result.SIM1 = &s
+	}
+	if sim2, ok := data["sim2_stats"].(map[string]interface{}); ok {
+		s := mapToNokiaSIMStats(sim2)
+		result.SIM2 = &s
+	}
+
 	return result
 }
 
+// mapToNokiaCellStatsSlice converts a generic JSON array of cell-stat
+// objects (as used by cell_5G_stats_sec/ca_stats) to typed cell stats,
+// skipping any entries that aren't objects.
+func (c *NokiaClient) mapToNokiaCellStatsSlice(raw []interface{}) []nokiaCellStats {
+	stats := make([]nokiaCellStats, 0, len(raw))
+	for _, item := range raw {
+		if m, ok := item.(map[string]interface{}); ok {
+			stats = append(stats, c.mapToNokiaCellStats(m))
+		}
+	}
+	return stats
+}
+
+// mapToNokiaSIMStats converts a generic map to nokiaSIMStats.
+func mapToNokiaSIMStats(data map[string]interface{}) nokiaSIMStats {
+	return nokiaSIMStats{
+		ICCID:  data["iccid"],
+		IMSI:   data["imsi"],
+		Active: data["active"],
+	}
+}
+
 // mapToNokiaCellStats converts a generic map to nokiaCellStats.
 func (c *NokiaClient) mapToNokiaCellStats(data map[string]interface{}) nokiaCellStats {
 	return nokiaCellStats{
@@ -213,7 +493,7 @@ func (c *NokiaClient) parseCellInfo(stats *nokiaCellStats) CellInfo {
 		ENB:       int64(c.parseNumeric(stats.CellID)),
 		TAC:       int64(c.parseNumeric(stats.TAC)),
 		Band:      c.parseBandNumber(stats.Band),
-		Bandwidth: fmt.Sprintf("%v", stats.Bandwidth),
+		Bandwidth: c.parseString(stats.Bandwidth),
 	}
 }
 
@@ -244,6 +524,20 @@ func (c *NokiaClient) parseNumeric(v interface{}) float64 {
 	return 0
 }
 
+// parseBool handles various truthy formats that may come from the API.
+func (c *NokiaClient) parseBool(v interface{}) bool {
+	switch val := v.(type) {
+	case bool:
+		return val
+	case float64:
+		return val != 0
+	case string:
+		val = strings.TrimSpace(strings.ToLower(val))
+		return val == "true" || val == "1" || val == "yes" || val == "active"
+	}
+	return false
+}
+
 // parseBandNumber extracts the band number from various formats.
 func (c *NokiaClient) parseBandNumber(v interface{}) int64 {
 	if v == nil {
@@ -267,12 +561,101 @@ func (c *NokiaClient) parseBandNumber(v interface{}) int64 {
 	return 0
 }
 
+// nokiaDHCPLease represents a single DHCP lease entry from the LAN lease
+// status endpoint.
+type nokiaDHCPLease struct {
+	MAC      string      `json:"MACAddr"`
+	Hostname string      `json:"hostName"`
+	IP       string      `json:"IPv4Addr"`
+	LeaseTTL interface{} `json:"leaseTimeRemaining"`
+}
+
+type nokiaDHCPLeaseStatus struct {
+	Leases []nokiaDHCPLease `json:"dhcp_lease_status_lan"`
+}
+
+// nokiaWifiClient represents a single associated WiFi client.
+type nokiaWifiClient struct {
+	MAC  string      `json:"MACAddr"`
+	RSSI interface{} `json:"RSSI"`
+}
+
+type nokiaWifiStatus struct {
+	Clients24G []nokiaWifiClient `json:"clients_2.4G"`
+	Clients5G  []nokiaWifiClient `json:"clients_5G"`
+	Clients6G  []nokiaWifiClient `json:"clients_6G"`
+}
+
+// GetDevices retrieves the connected-device inventory by merging the DHCP
+// lease table with WiFi client associations, keyed by MAC address. DHCP
+// leases not matched to a WiFi client are reported as LAN-attached.
+func (c *NokiaClient) GetDevices() ([]ConnectedDevice, error) {
+	now := time.Now()
+
+	leaseBody, err := c.authedGet("/dhcp_lease_status_lan.cgi")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get DHCP leases: %w", err)
+	}
+	var leaseStatus nokiaDHCPLeaseStatus
+	if err := json.Unmarshal(leaseBody, &leaseStatus); err != nil {
+		return nil, fmt.Errorf("failed to parse DHCP lease response: %w", err)
+	}
+
+	wifiBody, err := c.authedGet("/wifi_status_web_app.cgi")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get WiFi status: %w", err)
+	}
+	var wifiStatus nokiaWifiStatus
+	if err := json.Unmarshal(wifiBody, &wifiStatus); err != nil {
+		return nil, fmt.Errorf("failed to parse WiFi status response: %w", err)
+	}
+
+	rssiByMAC := make(map[string]float64)
+	ifaceByMAC := make(map[string]DeviceInterface)
+	for _, client := range wifiStatus.Clients24G {
+		rssiByMAC[client.MAC] = c.parseNumeric(client.RSSI)
+		ifaceByMAC[client.MAC] = DeviceInterfaceWifi24
+	}
+	for _, client := range wifiStatus.Clients5G {
+		rssiByMAC[client.MAC] = c.parseNumeric(client.RSSI)
+		ifaceByMAC[client.MAC] = DeviceInterfaceWifi5
+	}
+	for _, client := range wifiStatus.Clients6G {
+		rssiByMAC[client.MAC] = c.parseNumeric(client.RSSI)
+		ifaceByMAC[client.MAC] = DeviceInterfaceWifi6
+	}
+
+	devices := make([]ConnectedDevice, 0, len(leaseStatus.Leases))
+	for _, lease := range leaseStatus.Leases {
+		iface, ok := ifaceByMAC[lease.MAC]
+		if !ok {
+			iface = DeviceInterfaceLAN
+		}
+		devices = append(devices, ConnectedDevice{
+			MAC:         lease.MAC,
+			Hostname:    lease.Hostname,
+			IPv4:        lease.IP,
+			Interface:   iface,
+			RSSI:        rssiByMAC[lease.MAC],
+			LeaseExpiry: now.Add(time.Duration(c.parseNumeric(lease.LeaseTTL)) * time.Second),
+			FirstSeen:   now,
+			LastSeen:    now,
+		})
+	}
+
+	return devices, nil
+}
+
 // GetModel returns the gateway model type.
 func (c *NokiaClient) GetModel() GatewayModel {
 	return ModelNokia
 }
 
-// Close releases any resources held by the client.
+// Close releases any resources held by the client, stopping the token
+// renewal goroutine if one was started.
 func (c *NokiaClient) Close() error {
+	if c.tokens != nil {
+		return c.tokens.Close()
+	}
 	return nil
 }
@@ -1,16 +1,49 @@
 package gateway
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"time"
 )
 
 // SagemcomClient implements GatewayClient for Sagemcom gateways.
 // This is a stub implementation - actual Sagemcom gateway API details
 // would need to be added based on the specific model.
+//
+// Sagemcom's firmware gates the TMI endpoints behind a POST login that
+// returns a bearer token; see sagemcomAuthenticator.login() for the
+// handshake. The resulting token is cached and transparently renewed by a
+// tokenManager, so callers of GetDevices never see a re-auth.
 type SagemcomClient struct {
 	config     ClientConfig
 	httpClient *http.Client
+
+	// tokens is nil when no credentials are configured, in which case
+	// requests are made without an Authorization header.
+	tokens *tokenManager
+}
+
+// sagemcomAuthenticator implements authenticator for Sagemcom's TMI login
+// endpoint.
+type sagemcomAuthenticator struct {
+	config     ClientConfig
+	httpClient *http.Client
+}
+
+// sagemcomLoginRequest is POSTed to the login endpoint.
+type sagemcomLoginRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// sagemcomLoginResponse carries the bearer token issued after a successful
+// login and its time-to-live, in seconds.
+type sagemcomLoginResponse struct {
+	Token     string `json:"token"`
+	ExpiresIn int64  `json:"expiresIn"`
 }
 
 // NewSagemcomClient creates a new client for Sagemcom gateways.
@@ -18,10 +51,14 @@ func NewSagemcomClient(cfg ClientConfig, httpClient *http.Client) (*SagemcomClie
 	if httpClient == nil {
 		return nil, fmt.Errorf("httpClient is required")
 	}
-	return &SagemcomClient{
+	c := &SagemcomClient{
 		config:     cfg,
 		httpClient: httpClient,
-	}, nil
+	}
+	if cfg.Username != "" {
+		c.tokens = newTokenManager(&sagemcomAuthenticator{config: cfg, httpClient: httpClient})
+	}
+	return c, nil
 }
 
 // GetStatus retrieves the current gateway status.
@@ -43,12 +80,113 @@ func (c *SagemcomClient) GetStatus() (*GatewayStatus, error) {
 	return nil, fmt.Errorf("Sagemcom gateway support not yet implemented")
 }
 
+// sagemcomDeviceList represents the JSON response from the device
+// inventory endpoint.
+type sagemcomDeviceList struct {
+	Devices []sagemcomDevice `json:"devices"`
+}
+
+type sagemcomDevice struct {
+	MAC         string  `json:"macAddress"`
+	Hostname    string  `json:"hostName"`
+	IPv4        string  `json:"ipv4Address"`
+	IPv6        string  `json:"ipv6Address"`
+	Interface   string  `json:"interfaceType"`
+	RSSI        float64 `json:"rssi"`
+	LeaseExpiry int64   `json:"leaseExpiry"`
+}
+
+// GetDevices retrieves the connected-device inventory from the device
+// inventory endpoint.
+func (c *SagemcomClient) GetDevices() ([]ConnectedDevice, error) {
+	body, err := c.authedGet("/TMI/v1/network/devices")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get devices: %w", err)
+	}
+
+	var list sagemcomDeviceList
+	if err := json.Unmarshal(body, &list); err != nil {
+		return nil, fmt.Errorf("failed to parse JSON response: %w", err)
+	}
+
+	now := time.Now()
+	devices := make([]ConnectedDevice, 0, len(list.Devices))
+	for _, d := range list.Devices {
+		devices = append(devices, ConnectedDevice{
+			MAC:         d.MAC,
+			Hostname:    d.Hostname,
+			IPv4:        d.IPv4,
+			IPv6:        d.IPv6,
+			Interface:   parseDeviceInterface(d.Interface),
+			RSSI:        d.RSSI,
+			LeaseExpiry: now.Add(time.Duration(d.LeaseExpiry) * time.Second),
+			FirstSeen:   now,
+			LastSeen:    now,
+		})
+	}
+	return devices, nil
+}
+
+// authedGet issues a GET against endpoint with the session token attached,
+// logging in first if necessary and retrying once if the gateway reports
+// the token has expired. See the package-level authedGet for the shared
+// implementation used by every client.
+func (c *SagemcomClient) authedGet(endpoint string) ([]byte, error) {
+	return authedGet(c.tokens, c.httpClient, c.config.URL, endpoint)
+}
+
+// login performs Sagemcom's TMI login handshake: the configured
+// username/password are POSTed as JSON to /TMI/v1/auth/login, which
+// returns a bearer token and its expiry in seconds.
+func (a *sagemcomAuthenticator) login() (string, time.Duration, error) {
+	reqBody, err := json.Marshal(sagemcomLoginRequest{
+		Username: a.config.Username,
+		Password: a.config.Password,
+	})
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to build login request: %w", err)
+	}
+
+	resp, err := a.httpClient.Post(a.config.URL+"/TMI/v1/auth/login", "application/json", bytes.NewReader(reqBody))
+	if err != nil {
+		return "", 0, fmt.Errorf("login request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", 0, fmt.Errorf("login rejected with status %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to read login response: %w", err)
+	}
+
+	var session sagemcomLoginResponse
+	if err := json.Unmarshal(body, &session); err != nil {
+		return "", 0, fmt.Errorf("failed to parse login response: %w", err)
+	}
+	if session.Token == "" {
+		return "", 0, fmt.Errorf("login response did not include a session token")
+	}
+
+	ttl := defaultTokenTTL
+	if session.ExpiresIn > 0 {
+		ttl = time.Duration(session.ExpiresIn) * time.Second
+	}
+	return session.Token, ttl, nil
+}
+
 // GetModel returns the gateway model type.
 func (c *SagemcomClient) GetModel() GatewayModel {
 	return ModelSagemcom
 }
 
-// Close releases any resources held by the client.
+// Close releases any resources held by the client, stopping the token
+// renewal goroutine if one was started.
 func (c *SagemcomClient) Close() error {
+	if c.tokens != nil {
+		return c.tokens.Close()
+	}
 	return nil
 }
@@ -0,0 +1,49 @@
+package gateway
+
+import (
+	"context"
+	"time"
+)
+
+// Stream polls client on interval and delivers each successful GetStatus
+// onto the returned channel until ctx is cancelled, at which point the
+// channel is closed. Failed polls are skipped rather than sent, so
+// receivers never see a zero-value GatewayStatus.
+//
+// This is a free function rather than a GatewayClient method so existing
+// implementations don't need to grow polling logic of their own; it works
+// against any GatewayClient.
+func Stream(ctx context.Context, client GatewayClient, interval time.Duration) <-chan GatewayStatus {
+	ch := make(chan GatewayStatus)
+
+	go func() {
+		defer close(ch)
+
+		poll := func() {
+			status, err := client.GetStatus()
+			if err != nil {
+				return
+			}
+			select {
+			case ch <- *status:
+			case <-ctx.Done():
+			}
+		}
+
+		poll()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				poll()
+			}
+		}
+	}()
+
+	return ch
+}
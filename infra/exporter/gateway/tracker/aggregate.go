@@ -0,0 +1,82 @@
+package tracker
+
+import "time"
+
+// Aggregates summarizes signal quality and cell stability over a span of
+// archived samples.
+type Aggregates struct {
+	RSRPMin, RSRPMax, RSRPAvg float64
+	RSRQMin, RSRQMax, RSRQAvg float64
+	SINRMin, SINRMax, SINRAvg float64
+
+	// BandSeconds is how long the serving band (as of each sample) was
+	// observed, keyed by band number. Derived from gaps between
+	// consecutive samples, so it's only as accurate as the poll interval.
+	BandSeconds map[int64]float64
+
+	// HandoverCount is how many times the serving cell's PCI changed
+	// between consecutive samples.
+	HandoverCount int
+}
+
+// Aggregate computes rolling min/max/avg signal metrics, per-band dwell
+// time, and handover count for every archived sample in [from, to].
+func (t *Tracker) Aggregate(from, to time.Time) (Aggregates, error) {
+	records, err := t.queryRecords(from, to)
+	if err != nil {
+		return Aggregates{}, err
+	}
+	return aggregate(records), nil
+}
+
+func aggregate(records []Record) Aggregates {
+	agg := Aggregates{BandSeconds: make(map[int64]float64)}
+	if len(records) == 0 {
+		return agg
+	}
+
+	first := records[0].Status.Signal
+	agg.RSRPMin, agg.RSRPMax = first.RSRP, first.RSRP
+	agg.RSRQMin, agg.RSRQMax = first.RSRQ, first.RSRQ
+	agg.SINRMin, agg.SINRMax = first.SINR, first.SINR
+
+	var rsrpSum, rsrqSum, sinrSum float64
+	for i, r := range records {
+		sig := r.Status.Signal
+		rsrpSum += sig.RSRP
+		rsrqSum += sig.RSRQ
+		sinrSum += sig.SINR
+
+		agg.RSRPMin, agg.RSRPMax = min(agg.RSRPMin, sig.RSRP), max(agg.RSRPMax, sig.RSRP)
+		agg.RSRQMin, agg.RSRQMax = min(agg.RSRQMin, sig.RSRQ), max(agg.RSRQMax, sig.RSRQ)
+		agg.SINRMin, agg.SINRMax = min(agg.SINRMin, sig.SINR), max(agg.SINRMax, sig.SINR)
+
+		if i > 0 {
+			prev := records[i-1]
+			agg.BandSeconds[prev.Status.Cell.Band] += r.Time.Sub(prev.Time).Seconds()
+			if prev.Status.Cell.PCI != r.Status.Cell.PCI {
+				agg.HandoverCount++
+			}
+		}
+	}
+
+	n := float64(len(records))
+	agg.RSRPAvg = rsrpSum / n
+	agg.RSRQAvg = rsrqSum / n
+	agg.SINRAvg = sinrSum / n
+	return agg
+}
+
+func min(a, b float64) float64 {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max(a, b float64) float64 {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,195 @@
+package tracker
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tmobile-dashboard/exporter/gateway"
+)
+
+// compressStaleFiles gzips every day's archive file older than
+// Config.GzipAfter, skipping the file currently being written to. The
+// plain file is removed once compression succeeds.
+func (t *Tracker) compressStaleFiles() error {
+	if t.cfg.GzipAfter <= 0 {
+		return nil
+	}
+
+	entries, err := os.ReadDir(t.cfg.Dir)
+	if err != nil {
+		return fmt.Errorf("failed to list archive directory: %w", err)
+	}
+
+	cutoff := time.Now().Add(-t.cfg.GzipAfter)
+
+	for _, entry := range entries {
+		name := entry.Name()
+		if !strings.HasPrefix(name, "radio-") || !strings.HasSuffix(name, ".ndjson") {
+			continue
+		}
+
+		day := dayFromFilename(name)
+
+		t.mu.Lock()
+		isCurrent := day == t.currentDay
+		t.mu.Unlock()
+		if isCurrent {
+			continue
+		}
+
+		fileDay, err := time.Parse(dateLayout, day)
+		if err != nil || fileDay.After(cutoff) {
+			continue
+		}
+
+		if err := gzipFile(filepath.Join(t.cfg.Dir, name)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// gzipFile compresses path to path+".gz" and removes the original.
+func gzipFile(path string) error {
+	in, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open %s for compression: %w", path, err)
+	}
+	defer in.Close()
+
+	out, err := os.Create(path + ".gz")
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", path+".gz", err)
+	}
+
+	gw := gzip.NewWriter(out)
+	if _, err := io.Copy(gw, in); err != nil {
+		gw.Close()
+		out.Close()
+		return fmt.Errorf("failed to compress %s: %w", path, err)
+	}
+	if err := gw.Close(); err != nil {
+		out.Close()
+		return fmt.Errorf("failed to finalize %s: %w", path+".gz", err)
+	}
+	if err := out.Close(); err != nil {
+		return fmt.Errorf("failed to finalize %s: %w", path+".gz", err)
+	}
+
+	return os.Remove(path)
+}
+
+// dayFromFilename extracts the YYYY-MM-DD portion from a "radio-*.ndjson"
+// or "radio-*.ndjson.gz" file name.
+func dayFromFilename(name string) string {
+	name = strings.TrimPrefix(name, "radio-")
+	name = strings.TrimSuffix(name, ".gz")
+	return strings.TrimSuffix(name, ".ndjson")
+}
+
+// Query returns every archived status in [from, to], ordered oldest-first,
+// reading both plain and gzip-compressed day files as needed.
+func (t *Tracker) Query(from, to time.Time) ([]gateway.GatewayStatus, error) {
+	records, err := t.queryRecords(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]gateway.GatewayStatus, len(records))
+	for i, r := range records {
+		statuses[i] = r.Status
+	}
+	return statuses, nil
+}
+
+// queryRecords is like Query but keeps each sample's timestamp, which
+// Aggregate needs to compute band-dwell time.
+func (t *Tracker) queryRecords(from, to time.Time) ([]Record, error) {
+	entries, err := os.ReadDir(t.cfg.Dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list archive directory: %w", err)
+	}
+
+	// Day files are named by local date, so step through local days
+	// starting at midnight of from's day; stepping from from itself would
+	// skip to's day whenever to's time of day is earlier than from's.
+	wantedDays := make(map[string]bool)
+	fromLocal := from.Local()
+	start := time.Date(fromLocal.Year(), fromLocal.Month(), fromLocal.Day(), 0, 0, 0, 0, time.Local)
+	for d := start; !d.After(to); d = d.AddDate(0, 0, 1) {
+		wantedDays[d.Format(dateLayout)] = true
+	}
+
+	var paths []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if !strings.HasPrefix(name, "radio-") {
+			continue
+		}
+		if wantedDays[dayFromFilename(name)] {
+			paths = append(paths, filepath.Join(t.cfg.Dir, name))
+		}
+	}
+	sort.Strings(paths)
+
+	var records []Record
+	for _, path := range paths {
+		fileRecords, err := readRecords(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range fileRecords {
+			if r.Time.Before(from) || r.Time.After(to) {
+				continue
+			}
+			records = append(records, r)
+		}
+	}
+	return records, nil
+}
+
+// readRecords reads every Record from a plain or gzip-compressed ndjson file.
+func readRecords(path string) ([]Record, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	var r io.Reader = f
+	if strings.HasSuffix(path, ".gz") {
+		gr, err := gzip.NewReader(f)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decompress %s: %w", path, err)
+		}
+		defer gr.Close()
+		r = gr
+	}
+
+	var records []Record
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		var rec Record
+		if err := json.Unmarshal(line, &rec); err != nil {
+			return nil, fmt.Errorf("failed to parse record in %s: %w", path, err)
+		}
+		records = append(records, rec)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return records, nil
+}
@@ -0,0 +1,165 @@
+// Package tracker polls a gateway.GatewayClient on an interval and
+// archives each sample to a local, daily-rotating newline-delimited JSON
+// file, so signal history can be reviewed after the fact. The exporter's
+// one-shot GetStatus (and the `5g-inspector status` command) only show
+// what's happening right now; intermittent degradation needs a log.
+package tracker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/tmobile-dashboard/exporter/gateway"
+)
+
+const dateLayout = "2006-01-02"
+
+// defaultPollInterval is used when Config.PollInterval is zero.
+const defaultPollInterval = 30 * time.Second
+
+// Config configures a Tracker.
+type Config struct {
+	// Dir is the directory archive files are written to and read from.
+	Dir string
+
+	// PollInterval is how often the gateway is polled. Defaults to
+	// defaultPollInterval if zero.
+	PollInterval time.Duration
+
+	// GzipAfter is how long a day's file is left untouched before it's
+	// gzip-compressed. Zero disables compression.
+	GzipAfter time.Duration
+}
+
+// Record is a single archived poll, written as one line of
+// newline-delimited JSON.
+type Record struct {
+	Time    time.Time                 `json:"time"`
+	Status  gateway.GatewayStatus     `json:"status"`
+	Devices []gateway.ConnectedDevice `json:"devices,omitempty"`
+}
+
+// Tracker polls a GatewayClient and appends each sample to Config.Dir.
+type Tracker struct {
+	client gateway.GatewayClient
+	cfg    Config
+
+	mu          sync.Mutex
+	currentDay  string
+	currentFile *os.File
+}
+
+// New creates a Tracker that polls client and archives samples under
+// cfg.Dir, creating the directory if necessary.
+func New(client gateway.GatewayClient, cfg Config) (*Tracker, error) {
+	if cfg.Dir == "" {
+		return nil, fmt.Errorf("archive directory is required")
+	}
+	if err := os.MkdirAll(cfg.Dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create archive directory: %w", err)
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = defaultPollInterval
+	}
+	return &Tracker{client: client, cfg: cfg}, nil
+}
+
+// Run polls and archives samples until ctx is cancelled, compressing
+// stale archive files once an hour.
+func (t *Tracker) Run(ctx context.Context) error {
+	defer t.closeCurrentFile()
+
+	t.pollOnce()
+
+	pollTicker := time.NewTicker(t.cfg.PollInterval)
+	defer pollTicker.Stop()
+
+	gzipTicker := time.NewTicker(time.Hour)
+	defer gzipTicker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-pollTicker.C:
+			t.pollOnce()
+		case <-gzipTicker.C:
+			if err := t.compressStaleFiles(); err != nil {
+				log.Printf("tracker: failed to compress stale archive files: %v", err)
+			}
+		}
+	}
+}
+
+// pollOnce fetches the current status (and, best-effort, the connected
+// device inventory) and appends it to today's archive file.
+func (t *Tracker) pollOnce() {
+	now := time.Now()
+
+	status, err := t.client.GetStatus()
+	if err != nil {
+		log.Printf("tracker: failed to poll gateway status: %v", err)
+		return
+	}
+
+	record := Record{Time: now, Status: *status}
+	if devices, err := t.client.GetDevices(); err != nil {
+		log.Printf("tracker: failed to get connected devices: %v", err)
+	} else {
+		record.Devices = devices
+	}
+
+	if err := t.append(record); err != nil {
+		log.Printf("tracker: failed to archive sample: %v", err)
+	}
+}
+
+// append writes record as a single ndjson line, rotating to a new day's
+// file if necessary.
+func (t *Tracker) append(record Record) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode record: %w", err)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	day := record.Time.Format(dateLayout)
+	if day != t.currentDay || t.currentFile == nil {
+		if t.currentFile != nil {
+			t.currentFile.Close()
+		}
+		f, err := os.OpenFile(t.pathForDay(day), os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+		if err != nil {
+			return fmt.Errorf("failed to open archive file: %w", err)
+		}
+		t.currentFile = f
+		t.currentDay = day
+	}
+
+	if _, err := t.currentFile.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write record: %w", err)
+	}
+	return nil
+}
+
+// pathForDay returns the archive file path for the given YYYY-MM-DD day.
+func (t *Tracker) pathForDay(day string) string {
+	return filepath.Join(t.cfg.Dir, fmt.Sprintf("radio-%s.ndjson", day))
+}
+
+func (t *Tracker) closeCurrentFile() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.currentFile != nil {
+		t.currentFile.Close()
+		t.currentFile = nil
+	}
+}
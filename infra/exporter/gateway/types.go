@@ -1,6 +1,8 @@
 // Package gateway provides types and clients for communicating with T-Mobile gateway devices.
 package gateway
 
+import "time"
+
 // SignalMetrics contains the signal quality metrics from the gateway.
 type SignalMetrics struct {
 	// RSRP - Reference Signal Received Power (dBm)
@@ -47,14 +49,104 @@ type ConnectionInfo struct {
 	Status string
 }
 
+// CellRole identifies the role a reported cell plays in the current
+// radio configuration.
+//
+// A neighbor role (a detected-but-not-serving cell, reported for handover
+// evaluation) was considered but is out of scope: none of the supported
+// gateways' APIs expose neighbor-cell measurements distinct from the
+// serving/CA-secondary entries already covered by CellRolePrimary and
+// CellRoleSecondary, so there is nothing to populate it with.
+type CellRole string
+
+const (
+	// CellRolePrimary is the serving anchor cell for its RAT (the LTE
+	// anchor in NSA, or the NR leg).
+	CellRolePrimary CellRole = "primary"
+
+	// CellRoleSecondary is a component carrier aggregated onto the
+	// primary cell for carrier aggregation.
+	CellRoleSecondary CellRole = "secondary"
+)
+
+// RAT identifies the radio access technology of a reported cell.
+type RAT string
+
+const (
+	RATLTE RAT = "lte"
+	RATNR  RAT = "nr"
+)
+
+// CellReport describes a single serving or secondary cell, including the
+// RAT and carrier it was observed on. Gateways that support NSA carrier
+// aggregation report one CellReport per LTE anchor and NR leg, plus any
+// additional component carriers.
+type CellReport struct {
+	// Role is this cell's role in the current radio configuration.
+	Role CellRole
+
+	// RAT is the radio access technology (lte or nr) of this cell.
+	RAT RAT
+
+	// ARFCN is the absolute radio frequency channel number (EARFCN for
+	// LTE, NR-ARFCN for NR).
+	ARFCN int64
+
+	// Cell contains cell tower information.
+	Cell CellInfo
+
+	// Signal contains signal quality metrics for this cell.
+	Signal SignalMetrics
+}
+
+// SIMInfo describes a single SIM slot on a multi-SIM gateway.
+type SIMInfo struct {
+	// ICCID is the SIM card's integrated circuit card identifier.
+	ICCID string
+
+	// IMSIMasked is the subscriber's IMSI with everything past the
+	// MCC+MNC (the first 6 digits, which identify the home network)
+	// redacted.
+	IMSIMasked string
+
+	// SlotIndex is the SIM slot number (1-based).
+	SlotIndex int
+
+	// Active reports whether this SIM is the one currently in use.
+	Active bool
+}
+
 // GatewayStatus contains all metrics from the gateway.
 type GatewayStatus struct {
-	// Signal contains signal quality metrics
+	// Signal contains signal quality metrics for the primary serving cell.
 	Signal SignalMetrics
 
-	// Cell contains cell tower information
+	// Cell contains cell tower information for the primary serving cell.
 	Cell CellInfo
 
+	// Cells contains a report per serving and secondary cell, supporting
+	// NSA carrier aggregation where a gateway reports both an LTE anchor
+	// and one or more NR legs/component carriers.
+	Cells []CellReport
+
+	// SecondaryCells lists the component carriers aggregated onto the
+	// primary cell for carrier aggregation, if any. This is a flat
+	// subset of the CellRoleSecondary entries in Cells, for callers that
+	// only care about CA footprint and not each cell's RAT/role/ARFCN.
+	// Only NokiaClient fills it; other clients leave it empty even when
+	// Cells holds secondary entries.
+	SecondaryCells []CellInfo
+
+	// PrimarySignal is signal quality combined across the primary cell
+	// and every entry in SecondaryCells, so callers can reason about how
+	// much carrier aggregation is improving the connection rather than
+	// just reading the anchor cell's own numbers in Signal. Only
+	// NokiaClient fills it; other clients leave it zero.
+	PrimarySignal SignalMetrics
+
+	// SIMs lists the SIM slots on a multi-SIM gateway and which is active.
+	SIMs []SIMInfo
+
 	// Connection contains connection type and status
 	Connection ConnectionInfo
 
@@ -62,6 +154,48 @@ type GatewayStatus struct {
 	Model string
 }
 
+// DeviceInterface identifies which interface a connected device is
+// attached through.
+type DeviceInterface string
+
+const (
+	DeviceInterfaceLAN    DeviceInterface = "lan"
+	DeviceInterfaceWifi24 DeviceInterface = "2.4g"
+	DeviceInterfaceWifi5  DeviceInterface = "5g"
+	DeviceInterfaceWifi6  DeviceInterface = "6g"
+)
+
+// ConnectedDevice describes a single DHCP lease or WiFi client observed by
+// the gateway in one poll.
+type ConnectedDevice struct {
+	// MAC is the device's hardware address, used to correlate it across polls.
+	MAC string
+
+	// Hostname is the DHCP client hostname, if the device reported one.
+	Hostname string
+
+	// IPv4 and IPv6 are the device's leased/assigned addresses, where known.
+	IPv4 string
+	IPv6 string
+
+	// Interface is which interface the device is attached through.
+	Interface DeviceInterface
+
+	// RSSI is the WiFi signal strength in dBm, or 0 for wired devices and
+	// gateways that don't report it.
+	RSSI float64
+
+	// LeaseExpiry is when the device's DHCP lease expires.
+	LeaseExpiry time.Time
+
+	// FirstSeen and LastSeen reflect this poll only; a client has no
+	// memory of prior polls, so both are set to the time of this call.
+	// Callers that want true presence history across polls should track
+	// it themselves, e.g. with DiffDevices.
+	FirstSeen time.Time
+	LastSeen  time.Time
+}
+
 // GatewayModel represents supported gateway models.
 type GatewayModel string
 
@@ -77,6 +211,10 @@ type GatewayClient interface {
 	// GetStatus retrieves the current gateway status including signal metrics.
 	GetStatus() (*GatewayStatus, error)
 
+	// GetDevices retrieves the current connected-device inventory (DHCP
+	// leases and WiFi clients).
+	GetDevices() ([]ConnectedDevice, error)
+
 	// GetModel returns the gateway model type.
 	GetModel() GatewayModel
 
@@ -30,9 +30,11 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 
+	"github.com/tmobile-dashboard/exporter/archive"
 	"github.com/tmobile-dashboard/exporter/config"
 	"github.com/tmobile-dashboard/exporter/gateway"
 	"github.com/tmobile-dashboard/exporter/metrics"
+	"github.com/tmobile-dashboard/exporter/reload"
 )
 
 var (
@@ -65,44 +67,94 @@ func main() {
 	// Load environment variables
 	config.LoadConfigFromEnv(cfg)
 
-	// Override with command line flags
+	// Override with command line flags. These apply to the first
+	// configured gateway, matching the single-gateway shortcut used by
+	// -gateway/-model/-interval; multi-gateway setups should configure
+	// additional entries under "gateways" in the config file.
 	if *port != 0 {
 		cfg.Metrics.Port = *port
 	}
 	if *gatewayURL != "" {
-		cfg.Gateway.URL = *gatewayURL
+		cfg.Gateways[0].URL = *gatewayURL
 	}
 	if *model != "" {
-		cfg.Gateway.Model = *model
+		cfg.Gateways[0].Model = *model
 	}
 	if *interval != "" {
 		if d, err := time.ParseDuration(*interval); err == nil {
-			cfg.Gateway.PollInterval = d
+			cfg.Gateways[0].PollInterval = d
 		}
 	}
 
 	log.Printf("Starting T-Mobile Gateway Exporter %s", version)
-	log.Printf("Gateway URL: %s", cfg.Gateway.URL)
-	log.Printf("Gateway Model: %s", cfg.Gateway.Model)
-	log.Printf("Poll Interval: %s", cfg.Gateway.PollInterval)
+	log.Printf("Monitoring %d gateway(s)", len(cfg.Gateways))
 	log.Printf("Metrics Port: %d", cfg.Metrics.Port)
 
-	// Create gateway client
-	gwClient, err := gateway.NewClient(cfg.ToGatewayConfig())
-	if err != nil {
-		log.Fatalf("Failed to create gateway client: %v", err)
-	}
-	defer gwClient.Close()
+	// Create a gateway client per configured gateway.
+	var targets []metrics.GatewayTarget
+	for _, gwCfg := range cfg.Gateways {
+		device := gwCfg.DeviceName()
+
+		gwClient, err := gateway.NewClient(gwCfg.ToClientConfig())
+		if err != nil {
+			log.Fatalf("Failed to create gateway client for %s: %v", device, err)
+		}
 
-	log.Printf("Detected gateway model: %s", gwClient.GetModel())
+		log.Printf("Gateway %s: URL=%s detected model=%s", device, gwCfg.URL, gwClient.GetModel())
+		targets = append(targets, metrics.GatewayTarget{
+			Name:         device,
+			Client:       gwClient,
+			PollInterval: gwCfg.PollInterval,
+		})
+	}
 
 	// Create metrics collector
-	collector := metrics.NewCollector(gwClient)
+	collector := metrics.NewCollector(targets)
 	defer collector.Close()
 
+	// Optionally persist samples to a local historical archive and derive
+	// metrics (rolling RSRP average, handovers, band dwell time) that need
+	// more than the latest poll.
+	var samples *archive.Archive
+	if cfg.Archive.Enabled {
+		samples, err = archive.Open(cfg.Archive.Path, cfg.Archive.Retention)
+		if err != nil {
+			log.Fatalf("Failed to open archive: %v", err)
+		}
+		defer samples.Close()
+		log.Printf("Archiving samples to %s", cfg.Archive.Path)
+	}
+	archiveCollector := archive.NewCollector(samples)
+	prometheus.MustRegister(archiveCollector)
+
+	// Handle graceful shutdown
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Register before Start to avoid missing samples from targets that
+	// begin polling immediately.
+	collector.SetObserver(archiveCollector)
+
+	// Poll gateways in the background so scrapes are served from cache
+	// instead of blocking on the gateway's HTTP endpoint.
+	collector.Start(ctx)
+
 	// Register collector with Prometheus
 	prometheus.MustRegister(collector)
 
+	// Hot-reload the config file on write, swapping in new gateway
+	// clients without a restart.
+	if *configPath != "" {
+		configWatcher, err := reload.NewWatcher(*configPath, collector)
+		if err != nil {
+			log.Printf("Config hot-reload disabled: %v", err)
+		} else {
+			defer configWatcher.Close()
+			go configWatcher.Run(ctx)
+			log.Printf("Watching %s for config changes", *configPath)
+		}
+	}
+
 	// Create HTTP server
 	mux := http.NewServeMux()
 	mux.Handle(cfg.Metrics.Path, promhttp.Handler())
@@ -110,14 +162,20 @@ func main() {
 		w.WriteHeader(http.StatusOK)
 		w.Write([]byte("OK"))
 	})
+	if samples != nil {
+		mux.HandleFunc("/history", archive.HistoryHandler(samples))
+	}
 	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		var gatewayRows string
+		for _, target := range targets {
+			gatewayRows += "<li>" + target.Name + " (" + string(target.Client.GetModel()) + ")</li>"
+		}
 		w.Write([]byte(`<html>
 <head><title>T-Mobile Gateway Exporter</title></head>
 <body>
 <h1>T-Mobile Gateway Exporter</h1>
 <p>Version: ` + version + `</p>
-<p>Gateway: ` + cfg.Gateway.URL + `</p>
-<p>Model: ` + string(gwClient.GetModel()) + `</p>
+<ul>` + gatewayRows + `</ul>
 <p><a href="` + cfg.Metrics.Path + `">Metrics</a></p>
 </body>
 </html>`))
@@ -130,10 +188,6 @@ func main() {
 		WriteTimeout: 10 * time.Second,
 	}
 
-	// Handle graceful shutdown
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-
 	sigChan := make(chan os.Signal, 1)
 	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
 
@@ -2,18 +2,117 @@
 package metrics
 
 import (
+	"context"
 	"log"
+	"strconv"
 	"sync"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/tmobile-dashboard/exporter/gateway"
 )
 
+// defaultPollInterval is used when a GatewayTarget doesn't specify one.
+const defaultPollInterval = 5 * time.Second
+
+// SampleObserver is notified of every successful gateway poll, not just
+// the latest one Collect serves. The archive package implements this to
+// persist history and derive longer-horizon metrics.
+type SampleObserver interface {
+	Observe(device string, at time.Time, status *gateway.GatewayStatus)
+}
+
+// GatewayTarget pairs a gateway client with the device name it should be
+// labeled with in metrics and how often it should be polled, letting a
+// single Collector fan out across multiple gateways.
+type GatewayTarget struct {
+	// Name is the "device" label value for this gateway's metrics.
+	Name   string
+	Client gateway.GatewayClient
+
+	// PollInterval is how often this gateway is polled in the background.
+	// Defaults to defaultPollInterval if zero.
+	PollInterval time.Duration
+}
+
+// pollTarget wraps a GatewayTarget with the background polling goroutine
+// and cached result that decouple gateway scrapes from Prometheus
+// /metrics requests: Collect always serves the most recent poll instead
+// of blocking on the gateway's HTTP endpoint.
+type pollTarget struct {
+	GatewayTarget
+
+	cancel context.CancelFunc
+
+	cacheMu    sync.RWMutex
+	status     *gateway.GatewayStatus
+	err        error
+	lastScrape time.Time
+}
+
+func newPollTarget(t GatewayTarget) *pollTarget {
+	return &pollTarget{GatewayTarget: t}
+}
+
+// run polls the gateway immediately and then on PollInterval until ctx is
+// cancelled, notifying observe of every successful poll.
+func (t *pollTarget) run(ctx context.Context, observe func(device string, at time.Time, status *gateway.GatewayStatus)) {
+	t.scrapeOnce(observe)
+
+	interval := t.PollInterval
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			t.scrapeOnce(observe)
+		}
+	}
+}
+
+func (t *pollTarget) scrapeOnce(observe func(device string, at time.Time, status *gateway.GatewayStatus)) {
+	status, err := t.Client.GetStatus()
+	now := time.Now()
+
+	t.cacheMu.Lock()
+	t.status = status
+	t.err = err
+	t.lastScrape = now
+	t.cacheMu.Unlock()
+
+	if err != nil {
+		log.Printf("Error polling gateway %s: %v", t.Name, err)
+		return
+	}
+	if observe != nil {
+		observe(t.Name, now, status)
+	}
+}
+
+// snapshot returns the most recently cached poll result.
+func (t *pollTarget) snapshot() (status *gateway.GatewayStatus, err error, lastScrape time.Time) {
+	t.cacheMu.RLock()
+	defer t.cacheMu.RUnlock()
+	return t.status, t.err, t.lastScrape
+}
+
 // Collector implements prometheus.Collector for T-Mobile gateway metrics.
+// Call Start to begin background polling before registering it with
+// Prometheus.
 type Collector struct {
-	client gateway.GatewayClient
-	mu     sync.Mutex
+	mu       sync.Mutex
+	targets  []*pollTarget
+	ctx      context.Context
+	cancel   context.CancelFunc
+	wg       sync.WaitGroup
+	observer SampleObserver
 
 	// Signal metrics
 	rsrpDesc *prometheus.Desc
@@ -31,16 +130,38 @@ type Collector struct {
 	connectionTypeDesc *prometheus.Desc
 
 	// Scrape metrics
-	scrapeSuccessDesc *prometheus.Desc
+	scrapeSuccessDesc  *prometheus.Desc
 	scrapeDurationDesc *prometheus.Desc
+
+	// Per-device scrape metrics, for multi-gateway deployments.
+	collectorSuccessDesc  *prometheus.Desc
+	collectorDurationDesc *prometheus.Desc
+	lastScrapeDesc        *prometheus.Desc
+	gatewayUpDesc         *prometheus.Desc
+
+	// Per-cell metrics, labeled by rat/role/pci/band so NSA carrier
+	// aggregation setups can be graphed per leg (see GatewayStatus.Cells).
+	cellRSRPDesc  *prometheus.Desc
+	cellRSRQDesc  *prometheus.Desc
+	cellSINRDesc  *prometheus.Desc
+	cellRSSIDesc  *prometheus.Desc
+	cellARFCNDesc *prometheus.Desc
 }
 
-// NewCollector creates a new Collector with the given gateway client.
-func NewCollector(client gateway.GatewayClient) *Collector {
-	labels := []string{"model"}
+// NewCollector creates a new Collector for the given gateway targets. It
+// does not poll anything until Start is called.
+func NewCollector(targets []GatewayTarget) *Collector {
+	labels := []string{"model", "device"}
+	cellLabels := []string{"model", "device", "rat", "role", "pci", "band"}
+	deviceLabels := []string{"device"}
+
+	pollTargets := make([]*pollTarget, len(targets))
+	for i, t := range targets {
+		pollTargets[i] = newPollTarget(t)
+	}
 
 	return &Collector{
-		client: client,
+		targets: pollTargets,
 
 		// Signal metrics
 		rsrpDesc: prometheus.NewDesc(
@@ -115,9 +236,111 @@ func NewCollector(client gateway.GatewayClient) *Collector {
 			nil,
 			nil,
 		),
+
+		// Per-device scrape metrics
+		collectorSuccessDesc: prometheus.NewDesc(
+			"tmobile_scrape_collector_success",
+			"Whether the last background poll of this device's gateway was successful",
+			deviceLabels,
+			nil,
+		),
+		collectorDurationDesc: prometheus.NewDesc(
+			"tmobile_scrape_collector_duration_seconds",
+			"Seconds since this device's gateway was last polled in the background",
+			deviceLabels,
+			nil,
+		),
+		lastScrapeDesc: prometheus.NewDesc(
+			"tmobile_last_scrape_timestamp_seconds",
+			"Unix timestamp of the last background poll of this device's gateway",
+			deviceLabels,
+			nil,
+		),
+		gatewayUpDesc: prometheus.NewDesc(
+			"tmobile_gateway_up",
+			"Whether this device's gateway was reachable on the last background poll",
+			deviceLabels,
+			nil,
+		),
+
+		// Per-cell metrics
+		cellRSRPDesc: prometheus.NewDesc(
+			"tmobile_cell_rsrp",
+			"Reference Signal Received Power in dBm, per serving/secondary cell",
+			cellLabels,
+			nil,
+		),
+		cellRSRQDesc: prometheus.NewDesc(
+			"tmobile_cell_rsrq",
+			"Reference Signal Received Quality in dB, per serving/secondary cell",
+			cellLabels,
+			nil,
+		),
+		cellSINRDesc: prometheus.NewDesc(
+			"tmobile_cell_sinr",
+			"Signal to Interference Noise Ratio in dB, per serving/secondary cell",
+			cellLabels,
+			nil,
+		),
+		cellRSSIDesc: prometheus.NewDesc(
+			"tmobile_cell_rssi",
+			"Received Signal Strength Indicator in dBm, per serving/secondary cell",
+			cellLabels,
+			nil,
+		),
+		cellARFCNDesc: prometheus.NewDesc(
+			"tmobile_cell_arfcn",
+			"Absolute radio frequency channel number, per serving/secondary cell",
+			cellLabels,
+			nil,
+		),
+	}
+}
+
+// Start launches a background polling goroutine per gateway target. The
+// goroutines stop when ctx is cancelled or Close is called.
+func (c *Collector) Start(ctx context.Context) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.ctx, c.cancel = context.WithCancel(ctx)
+	for _, t := range c.targets {
+		c.startTargetLocked(t)
+	}
+}
+
+// startTargetLocked must be called with c.mu held and c.ctx already set.
+func (c *Collector) startTargetLocked(t *pollTarget) {
+	tctx, cancel := context.WithCancel(c.ctx)
+	t.cancel = cancel
+
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		t.run(tctx, c.observeSample)
+	}()
+}
+
+// observeSample forwards a successful poll to the configured SampleObserver, if any.
+func (c *Collector) observeSample(device string, at time.Time, status *gateway.GatewayStatus) {
+	c.mu.Lock()
+	observer := c.observer
+	c.mu.Unlock()
+
+	if observer != nil {
+		observer.Observe(device, at, status)
 	}
 }
 
+// SetObserver registers a SampleObserver to be notified of every
+// successful poll. Must be called before Start to avoid missing samples
+// from targets that begin polling immediately.
+func (c *Collector) SetObserver(observer SampleObserver) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.observer = observer
+}
+
 // Describe implements prometheus.Collector.
 func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- c.rsrpDesc
@@ -131,53 +354,145 @@ func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
 	ch <- c.connectionTypeDesc
 	ch <- c.scrapeSuccessDesc
 	ch <- c.scrapeDurationDesc
+	ch <- c.collectorSuccessDesc
+	ch <- c.collectorDurationDesc
+	ch <- c.lastScrapeDesc
+	ch <- c.gatewayUpDesc
+	ch <- c.cellRSRPDesc
+	ch <- c.cellRSRQDesc
+	ch <- c.cellSINRDesc
+	ch <- c.cellRSSIDesc
+	ch <- c.cellARFCNDesc
 }
 
-// Collect implements prometheus.Collector.
+// Collect implements prometheus.Collector. It serves the most recently
+// cached poll of each gateway target rather than hitting the gateway's
+// HTTP endpoint inline, so a slow or unresponsive gateway can't block a
+// Prometheus scrape.
 func (c *Collector) Collect(ch chan<- prometheus.Metric) {
 	c.mu.Lock()
-	defer c.mu.Unlock()
+	targets := c.targets
+	c.mu.Unlock()
 
 	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(v float64) {
 		ch <- prometheus.MustNewConstMetric(c.scrapeDurationDesc, prometheus.GaugeValue, v)
 	}))
 	defer timer.ObserveDuration()
 
-	status, err := c.client.GetStatus()
-	if err != nil {
-		log.Printf("Error collecting metrics: %v", err)
-		ch <- prometheus.MustNewConstMetric(c.scrapeSuccessDesc, prometheus.GaugeValue, 0)
-		return
+	overallSuccess := 1.0
+	for _, t := range targets {
+		status, err, lastScrape := t.snapshot()
+
+		ch <- prometheus.MustNewConstMetric(c.lastScrapeDesc, prometheus.GaugeValue, float64(lastScrape.Unix()), t.Name)
+		ch <- prometheus.MustNewConstMetric(c.collectorDurationDesc, prometheus.GaugeValue, time.Since(lastScrape).Seconds(), t.Name)
+
+		if err != nil || status == nil {
+			ch <- prometheus.MustNewConstMetric(c.collectorSuccessDesc, prometheus.GaugeValue, 0, t.Name)
+			ch <- prometheus.MustNewConstMetric(c.gatewayUpDesc, prometheus.GaugeValue, 0, t.Name)
+			overallSuccess = 0
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(c.collectorSuccessDesc, prometheus.GaugeValue, 1, t.Name)
+		ch <- prometheus.MustNewConstMetric(c.gatewayUpDesc, prometheus.GaugeValue, 1, t.Name)
+
+		c.collectStatus(ch, t.Name, status)
 	}
 
-	ch <- prometheus.MustNewConstMetric(c.scrapeSuccessDesc, prometheus.GaugeValue, 1)
+	ch <- prometheus.MustNewConstMetric(c.scrapeSuccessDesc, prometheus.GaugeValue, overallSuccess)
+}
 
+// collectStatus emits the metrics for a single gateway's status, labeled
+// with its device name.
+func (c *Collector) collectStatus(ch chan<- prometheus.Metric, device string, status *gateway.GatewayStatus) {
 	model := status.Model
 
 	// Signal metrics
-	ch <- prometheus.MustNewConstMetric(c.rsrpDesc, prometheus.GaugeValue, status.Signal.RSRP, model)
-	ch <- prometheus.MustNewConstMetric(c.rsrqDesc, prometheus.GaugeValue, status.Signal.RSRQ, model)
-	ch <- prometheus.MustNewConstMetric(c.sinrDesc, prometheus.GaugeValue, status.Signal.SINR, model)
-	ch <- prometheus.MustNewConstMetric(c.rssiDesc, prometheus.GaugeValue, status.Signal.RSSI, model)
+	ch <- prometheus.MustNewConstMetric(c.rsrpDesc, prometheus.GaugeValue, status.Signal.RSRP, model, device)
+	ch <- prometheus.MustNewConstMetric(c.rsrqDesc, prometheus.GaugeValue, status.Signal.RSRQ, model, device)
+	ch <- prometheus.MustNewConstMetric(c.sinrDesc, prometheus.GaugeValue, status.Signal.SINR, model, device)
+	ch <- prometheus.MustNewConstMetric(c.rssiDesc, prometheus.GaugeValue, status.Signal.RSSI, model, device)
 
 	// Cell metrics
-	ch <- prometheus.MustNewConstMetric(c.pciDesc, prometheus.GaugeValue, float64(status.Cell.PCI), model)
-	ch <- prometheus.MustNewConstMetric(c.enbDesc, prometheus.GaugeValue, float64(status.Cell.ENB), model)
-	ch <- prometheus.MustNewConstMetric(c.tacDesc, prometheus.GaugeValue, float64(status.Cell.TAC), model)
-	ch <- prometheus.MustNewConstMetric(c.bandDesc, prometheus.GaugeValue, float64(status.Cell.Band), model)
+	ch <- prometheus.MustNewConstMetric(c.pciDesc, prometheus.GaugeValue, float64(status.Cell.PCI), model, device)
+	ch <- prometheus.MustNewConstMetric(c.enbDesc, prometheus.GaugeValue, float64(status.Cell.ENB), model, device)
+	ch <- prometheus.MustNewConstMetric(c.tacDesc, prometheus.GaugeValue, float64(status.Cell.TAC), model, device)
+	ch <- prometheus.MustNewConstMetric(c.bandDesc, prometheus.GaugeValue, float64(status.Cell.Band), model, device)
 
 	// Connection type (1 = 4G/LTE, 2 = 5G)
 	connectionType := 1.0
 	if status.Connection.Type == "5G" {
 		connectionType = 2.0
 	}
-	ch <- prometheus.MustNewConstMetric(c.connectionTypeDesc, prometheus.GaugeValue, connectionType, model)
+	ch <- prometheus.MustNewConstMetric(c.connectionTypeDesc, prometheus.GaugeValue, connectionType, model, device)
+
+	for _, cell := range status.Cells {
+		pci := strconv.FormatInt(cell.Cell.PCI, 10)
+		band := strconv.FormatInt(cell.Cell.Band, 10)
+		labels := []string{model, device, string(cell.RAT), string(cell.Role), pci, band}
+
+		ch <- prometheus.MustNewConstMetric(c.cellRSRPDesc, prometheus.GaugeValue, cell.Signal.RSRP, labels...)
+		ch <- prometheus.MustNewConstMetric(c.cellRSRQDesc, prometheus.GaugeValue, cell.Signal.RSRQ, labels...)
+		ch <- prometheus.MustNewConstMetric(c.cellSINRDesc, prometheus.GaugeValue, cell.Signal.SINR, labels...)
+		ch <- prometheus.MustNewConstMetric(c.cellRSSIDesc, prometheus.GaugeValue, cell.Signal.RSSI, labels...)
+		ch <- prometheus.MustNewConstMetric(c.cellARFCNDesc, prometheus.GaugeValue, float64(cell.ARFCN), labels...)
+	}
+}
+
+// SwapTargets atomically replaces the gateway targets this Collector
+// polls and returns the previous ones, so the caller can close them once
+// they're no longer in use. If Start has already been called, background
+// polling for the new targets begins immediately and polling for the old
+// ones is stopped. Used by the config hot-reloader.
+func (c *Collector) SwapTargets(targets []GatewayTarget) []GatewayTarget {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	old := c.targets
+
+	newTargets := make([]*pollTarget, len(targets))
+	for i, t := range targets {
+		newTargets[i] = newPollTarget(t)
+	}
+	c.targets = newTargets
+
+	for _, t := range old {
+		if t.cancel != nil {
+			t.cancel()
+		}
+	}
+	if c.ctx != nil {
+		for _, t := range newTargets {
+			c.startTargetLocked(t)
+		}
+	}
+
+	result := make([]GatewayTarget, len(old))
+	for i, t := range old {
+		result[i] = t.GatewayTarget
+	}
+	return result
 }
 
-// Close releases resources held by the collector.
+// Close stops all background polling and releases resources held by
+// every gateway target.
 func (c *Collector) Close() error {
-	if c.client != nil {
-		return c.client.Close()
+	c.mu.Lock()
+	if c.cancel != nil {
+		c.cancel()
+	}
+	targets := c.targets
+	c.mu.Unlock()
+
+	c.wg.Wait()
+
+	var firstErr error
+	for _, t := range targets {
+		if t.Client == nil {
+			continue
+		}
+		if err := t.Client.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
 	}
-	return nil
+	return firstErr
 }
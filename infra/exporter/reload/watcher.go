@@ -0,0 +1,127 @@
+// Package reload hot-reloads the exporter's YAML config file so that
+// changing the poll interval, gateway URL, or credentials doesn't require
+// restarting the process.
+package reload
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/tmobile-dashboard/exporter/config"
+	"github.com/tmobile-dashboard/exporter/gateway"
+	"github.com/tmobile-dashboard/exporter/metrics"
+)
+
+// Watcher watches a config file path for writes and, on each one,
+// re-parses it and atomically swaps in freshly-built gateway clients on
+// the collector it was created with. If parsing or client creation fails,
+// the previous configuration keeps running untouched.
+type Watcher struct {
+	path      string
+	collector *metrics.Collector
+	fsWatcher *fsnotify.Watcher
+
+	reloadTotal *prometheus.CounterVec
+}
+
+// NewWatcher starts watching path for changes. The caller must call Run to
+// process events and Close to release the underlying fsnotify watcher.
+func NewWatcher(path string, collector *metrics.Collector) (*Watcher, error) {
+	fsWatcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create fsnotify watcher: %w", err)
+	}
+	if err := fsWatcher.Add(path); err != nil {
+		fsWatcher.Close()
+		return nil, fmt.Errorf("failed to watch config file %s: %w", path, err)
+	}
+
+	reloadTotal := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "tmobile_config_reload_total",
+			Help: "Count of config file reload attempts, by result (success or failure)",
+		},
+		[]string{"result"},
+	)
+	prometheus.MustRegister(reloadTotal)
+
+	return &Watcher{
+		path:        path,
+		collector:   collector,
+		fsWatcher:   fsWatcher,
+		reloadTotal: reloadTotal,
+	}, nil
+}
+
+// Run processes fsnotify events until ctx is cancelled or the watcher is closed.
+func (w *Watcher) Run(ctx context.Context) {
+	for {
+		select {
+		case event, ok := <-w.fsWatcher.Events:
+			if !ok {
+				return
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+				continue
+			}
+			w.reload()
+		case err, ok := <-w.fsWatcher.Errors:
+			if !ok {
+				return
+			}
+			log.Printf("config watcher error: %v", err)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// reload re-parses the config file, builds a new client per gateway, and
+// swaps them into the collector. On any failure it leaves the running
+// configuration untouched.
+func (w *Watcher) reload() {
+	cfg, err := config.LoadConfig(w.path)
+	if err != nil {
+		log.Printf("config reload failed: %v", err)
+		w.reloadTotal.WithLabelValues("failure").Inc()
+		return
+	}
+	config.LoadConfigFromEnv(cfg)
+
+	targets := make([]metrics.GatewayTarget, 0, len(cfg.Gateways))
+	for _, gwCfg := range cfg.Gateways {
+		client, err := gateway.NewClient(gwCfg.ToClientConfig())
+		if err != nil {
+			log.Printf("config reload failed: could not create client for %s: %v", gwCfg.DeviceName(), err)
+			w.reloadTotal.WithLabelValues("failure").Inc()
+			for _, target := range targets {
+				target.Client.Close()
+			}
+			return
+		}
+		targets = append(targets, metrics.GatewayTarget{
+			Name:         gwCfg.DeviceName(),
+			Client:       client,
+			PollInterval: gwCfg.PollInterval,
+		})
+	}
+
+	old := w.collector.SwapTargets(targets)
+	for _, target := range old {
+		if target.Client != nil {
+			target.Client.Close()
+		}
+	}
+
+	log.Printf("reloaded config from %s", w.path)
+	w.reloadTotal.WithLabelValues("success").Inc()
+}
+
+// Close releases the underlying fsnotify watcher.
+func (w *Watcher) Close() error {
+	return w.fsWatcher.Close()
+}
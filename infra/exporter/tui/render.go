@@ -0,0 +1,87 @@
+// Package tui renders gateway.GatewayStatus as a human-readable terminal
+// dashboard, used by the `5g-inspector status` command.
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/tmobile-dashboard/exporter/gateway"
+)
+
+// quality bands for color-coding, based on the typical ranges documented
+// on gateway.SignalMetrics.
+const (
+	ansiGreen  = "\033[32m"
+	ansiYellow = "\033[33m"
+	ansiRed    = "\033[31m"
+	ansiReset  = "\033[0m"
+)
+
+// band returns a color-coded quality label (good/fair/poor) for a metric
+// value, given descending good/fair thresholds.
+func band(value, goodAt, fairAt float64) string {
+	switch {
+	case value >= goodAt:
+		return ansiGreen + "good" + ansiReset
+	case value >= fairAt:
+		return ansiYellow + "fair" + ansiReset
+	default:
+		return ansiRed + "poor" + ansiReset
+	}
+}
+
+func rsrpBand(v float64) string { return band(v, -90, -105) }
+func rsrqBand(v float64) string { return band(v, -10, -15) }
+func sinrBand(v float64) string { return band(v, 13, 0) }
+func rssiBand(v float64) string { return band(v, -65, -85) }
+
+// sparkChars are used to render a rolling value history as a single line.
+var sparkChars = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline renders samples as a single line of block characters scaled
+// between the min and max of the series.
+func Sparkline(samples []float64) string {
+	if len(samples) == 0 {
+		return ""
+	}
+	min, max := samples[0], samples[0]
+	for _, s := range samples {
+		if s < min {
+			min = s
+		}
+		if s > max {
+			max = s
+		}
+	}
+
+	var sb strings.Builder
+	for _, s := range samples {
+		idx := len(sparkChars) - 1
+		if max > min {
+			idx = int((s - min) / (max - min) * float64(len(sparkChars)-1))
+		}
+		sb.WriteRune(sparkChars[idx])
+	}
+	return sb.String()
+}
+
+// Dashboard renders a live-terminal-style status view for a single gateway
+// poll, including a rolling SINR sparkline. sinrHistory is sampled once
+// per pollInterval, which is used to label the sparkline's time window.
+func Dashboard(status *gateway.GatewayStatus, sinrHistory []float64, pollInterval time.Duration) string {
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "Gateway: %s (%s)\n", status.Model, status.Connection.Type)
+	fmt.Fprintf(&sb, "  RSRP: %7.1f dBm  [%s]\n", status.Signal.RSRP, rsrpBand(status.Signal.RSRP))
+	fmt.Fprintf(&sb, "  RSRQ: %7.1f dB   [%s]\n", status.Signal.RSRQ, rsrqBand(status.Signal.RSRQ))
+	fmt.Fprintf(&sb, "  SINR: %7.1f dB   [%s]\n", status.Signal.SINR, sinrBand(status.Signal.SINR))
+	fmt.Fprintf(&sb, "  RSSI: %7.1f dBm  [%s]\n", status.Signal.RSSI, rssiBand(status.Signal.RSSI))
+	fmt.Fprintf(&sb, "  PCI: %d  eNB: %d  TAC: %d  Band: %d\n",
+		status.Cell.PCI, status.Cell.ENB, status.Cell.TAC, status.Cell.Band)
+	windowSeconds := int(float64(len(sinrHistory)) * pollInterval.Seconds())
+	fmt.Fprintf(&sb, "  SINR (last %ds): %s\n", windowSeconds, Sparkline(sinrHistory))
+
+	return sb.String()
+}